@@ -0,0 +1,75 @@
+package channel
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkMap(b *testing.B) {
+	for _, bufferSize := range []int{0, 16, 256} {
+		b.Run(fmt.Sprintf("buffer_%d", bufferSize), func(b *testing.B) {
+			input := Range(0, b.N)
+			mapped := Map(input, func(i int) int { return i * 2 }, WithBuffer(bufferSize))
+			filtered := Filter(mapped, func(i int) bool { return i%3 == 0 }, WithBuffer(bufferSize))
+			b.ResetTimer()
+			for range filtered {
+			}
+		})
+	}
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	double := func(i int) int { return i * 2 }
+	b.Run("unchunked", func(b *testing.B) {
+		mapped := ParallelMap(Range(0, b.N), double)
+		b.ResetTimer()
+		for range mapped {
+		}
+	})
+	for _, chunkSize := range []int{16, 256, 4096} {
+		b.Run(fmt.Sprintf("chunk_%d", chunkSize), func(b *testing.B) {
+			mapped := ParallelMapChunked(Range(0, b.N), double, chunkSize)
+			b.ResetTimer()
+			for range mapped {
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	filtered := Filter(Range(0, b.N), func(i int) bool { return i%2 == 0 })
+	b.ResetTimer()
+	for range filtered {
+	}
+}
+
+func BenchmarkFlatMap(b *testing.B) {
+	flat := FlatMap(Range(0, b.N/4+1), func(i int) <-chan int { return Range(i, i+4) })
+	b.ResetTimer()
+	for range flat {
+	}
+}
+
+func BenchmarkToSlice(b *testing.B) {
+	input := Range(0, b.N)
+	b.ResetTimer()
+	ToSlice(input)
+}
+
+func BenchmarkReduce(b *testing.B) {
+	input := Range(0, b.N)
+	b.ResetTimer()
+	Reduce(input, func(a, b int) int { return a + b }, 0)
+}
+
+func BenchmarkSum(b *testing.B) {
+	input := Range(0, b.N)
+	b.ResetTimer()
+	Sum(input)
+}
+
+func BenchmarkCount(b *testing.B) {
+	input := Range(0, b.N)
+	b.ResetTimer()
+	Count(input)
+}
@@ -0,0 +1,51 @@
+package channel
+
+// BufferWithWatermarks buffers up to capacity values of channel while the
+// consumer is busy, blocking the producer only once the buffer is full. When
+// the number of buffered values rises to high, onHigh is called with it, after
+// which onLow is called once it has fallen back to low, and so on. This lets
+// producers be slowed down or alerts be raised before the buffer fills up.
+// The callbacks are called from the buffering goroutine, so they should not
+// block. A capacity less than 1 is treated as 1.
+func BufferWithWatermarks[T any](channel <-chan T, capacity, high, low int, onHigh, onLow func(level int), opts ...Option) <-chan T {
+	o := newOptions(opts)
+	out := makeChan[T](o)
+	go func() {
+		defer close(out)
+		buf := newRing[T](max(capacity, 1))
+		aboveHigh := false
+		in := channel
+		for in != nil || buf.len() > 0 {
+			receiveFrom := in
+			if buf.full() {
+				receiveFrom = nil
+			}
+			var sendTo chan T
+			var next T
+			if buf.len() > 0 {
+				sendTo, next = out, buf.peek()
+			}
+			select {
+			case t, ok := <-receiveFrom:
+				if !ok {
+					in = nil
+					continue
+				}
+				buf.push(t)
+				if !aboveHigh && buf.len() >= high {
+					aboveHigh = true
+					onHigh(buf.len())
+				}
+			case sendTo <- next:
+				buf.pop()
+				if aboveHigh && buf.len() <= low {
+					aboveHigh = false
+					onLow(buf.len())
+				}
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
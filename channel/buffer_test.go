@@ -0,0 +1,90 @@
+package channel
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestBufferWithWatermarks(t *testing.T) {
+	t.Parallel()
+
+	// receive is a step of a script that receives a value instead of sending one
+	const receive = -1
+	cases := []struct {
+		name       string
+		script     []int
+		capacity   int
+		high       int
+		low        int
+		want       []int
+		wantEvents []string
+	}{
+		{
+			name:       "empty",
+			script:     []int{},
+			capacity:   4,
+			high:       3,
+			low:        1,
+			want:       nil,
+			wantEvents: nil,
+		},
+		{
+			name:       "below_high",
+			script:     []int{1, 2, receive, 3},
+			capacity:   4,
+			high:       3,
+			low:        1,
+			want:       []int{1, 2, 3},
+			wantEvents: nil,
+		},
+		{
+			name:       "crosses_high_then_low",
+			script:     []int{1, 2, 3, receive, receive, 4, 5, receive},
+			capacity:   4,
+			high:       3,
+			low:        1,
+			want:       []int{1, 2, 3, 4, 5},
+			wantEvents: []string{"high:3", "low:1", "high:3", "low:1"},
+		},
+		{
+			name:       "fills_up",
+			script:     []int{1, 2, 3, 4, receive, 5},
+			capacity:   4,
+			high:       4,
+			low:        2,
+			want:       []int{1, 2, 3, 4, 5},
+			wantEvents: []string{"high:4", "low:2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			// a single goroutine sending and receiving leaves the buffer only
+			// one step it can take at a time, making the levels deterministic
+			var events []string
+			input := make(chan int)
+			buffered := BufferWithWatermarks(input, tc.capacity, tc.high, tc.low,
+				func(level int) { events = append(events, fmt.Sprintf("high:%d", level)) },
+				func(level int) { events = append(events, fmt.Sprintf("low:%d", level)) },
+			)
+			var got []int
+			for _, step := range tc.script {
+				if step == receive {
+					got = append(got, <-buffered)
+				} else {
+					input <- step
+				}
+			}
+			close(input)
+			got = ToSliceInto(buffered, got)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(events, tc.wantEvents); diff != "" {
+				t.Errorf("unexpected events (-got, +want): %s", diff)
+			}
+		})
+	}
+}
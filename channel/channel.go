@@ -1,9 +1,15 @@
 package channel
 
 import (
+	"context"
 	"errors"
+	"github.com/lock14/functional/option"
+	"github.com/lock14/functional/result"
+	"github.com/lock14/functional/try"
+	"github.com/lock14/functional/tuple"
 	"golang.org/x/exp/constraints"
 	"iter"
+	"math/rand/v2"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -15,48 +21,83 @@ type Monad interface {
 	constraints.Integer | constraints.Float | constraints.Complex | ~string
 }
 
-func Map[T, U any](channel chan T, f func(T) U) chan U {
-	mapped := make(chan U)
+func Map[T, U any](channel <-chan T, f func(T) U, opts ...Option) <-chan U {
+	o := newOptions(opts)
+	mapped := makeChan[U](o)
 	go func() {
-		for t := range channel {
-			mapped <- f(t)
+		defer close(mapped)
+		for t := range receiveAll(o.ctx, channel) {
+			if !send(o.ctx, mapped, f(t)) {
+				return
+			}
 		}
-		close(mapped)
 	}()
 	return mapped
 }
 
-func Flatten[T any](channels chan chan T) chan T {
-	flat := make(chan T)
+func Flatten[T any](channels <-chan <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	flat := makeChan[T](o)
 	go func() {
-		for channel := range channels {
-			for t := range channel {
-				flat <- t
+		defer close(flat)
+		for channel := range receiveAll(o.ctx, channels) {
+			for t := range receiveAll(o.ctx, channel) {
+				if !send(o.ctx, flat, t) {
+					return
+				}
 			}
 		}
-		close(flat)
 	}()
 	return flat
 }
 
-func FlatMap[T, U any](channel chan T, f func(T) chan U) chan U {
-	return Flatten(Map(channel, f))
+func FlatMap[T, U any](channel <-chan T, f func(T) <-chan U, opts ...Option) <-chan U {
+	return Flatten(Map(channel, f, opts...), opts...)
 }
 
-func Filter[T any](channel chan T, p func(T) bool) chan T {
-	filtered := make(chan T)
+func Filter[T any](channel <-chan T, p func(T) bool, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	filtered := makeChan[T](o)
 	go func() {
-		for t := range channel {
-			if p(t) {
-				filtered <- t
+		defer close(filtered)
+		for t := range receiveAll(o.ctx, channel) {
+			if p(t) && !send(o.ctx, filtered, t) {
+				return
 			}
 		}
-		close(filtered)
 	}()
 	return filtered
 }
 
-func FoldLeft[T, U any](channel chan T, f func(u U, t T) U, u U) U {
+// FilterMap transforms and filters channel in a single pass, sending f(t) for
+// every t for which f reports true.
+func FilterMap[T, U any](channel <-chan T, f func(T) (U, bool), opts ...Option) <-chan U {
+	o := newOptions(opts)
+	mapped := makeChan[U](o)
+	go func() {
+		defer close(mapped)
+		for t := range receiveAll(o.ctx, channel) {
+			if u, ok := f(t); ok && !send(o.ctx, mapped, u) {
+				return
+			}
+		}
+	}()
+	return mapped
+}
+
+func MapOption[T, U any](channel <-chan T, f func(T) option.Option[U], opts ...Option) <-chan U {
+	return FilterMap(channel, func(t T) (U, bool) { return f(t).Get() }, opts...)
+}
+
+// MapTry is like Map, but a panic in f is sent downstream as an error instead
+// of crashing the program.
+func MapTry[T, U any](channel <-chan T, f func(T) U, opts ...Option) <-chan result.Result[U] {
+	return Map(channel, func(t T) result.Result[U] {
+		return try.Of(func() U { return f(t) })
+	}, opts...)
+}
+
+func FoldLeft[T, U any](channel <-chan T, f func(u U, t T) U, u U) U {
 	result := u
 	for t := range channel {
 		result = f(result, t)
@@ -64,7 +105,7 @@ func FoldLeft[T, U any](channel chan T, f func(u U, t T) U, u U) U {
 	return result
 }
 
-func FoldRight[T, U any](channel chan T, f func(t T, u U) U, u U) U {
+func FoldRight[T, U any](channel <-chan T, f func(t T, u U) U, u U) U {
 	result := u
 	for t := range channel {
 		result = f(t, FoldRight[T, U](channel, f, u))
@@ -72,20 +113,68 @@ func FoldRight[T, U any](channel chan T, f func(t T, u U) U, u U) U {
 	return result
 }
 
-func Reduce[T any](channel chan T, op func(t1, t2 T) T, initial T) T {
-	return FoldLeft(channel, op, initial)
+func Reduce[T any](channel <-chan T, op func(t1, t2 T) T, initial T) T {
+	result := initial
+	for t := range channel {
+		result = op(result, t)
+	}
+	return result
+}
+
+// Scan is like FoldLeft but emits every intermediate state, starting with the
+// state after the first value.
+func Scan[T, S any](channel <-chan T, initial S, step func(S, T) S, opts ...Option) <-chan S {
+	return MapStateful(channel, initial, func(s S, t T) (S, S) {
+		next := step(s, t)
+		return next, next
+	}, opts...)
+}
+
+// MapStateful maps every value of channel while carrying a state from one
+// value to the next, starting with initial.
+func MapStateful[T, S, U any](channel <-chan T, initial S, f func(S, T) (S, U), opts ...Option) <-chan U {
+	o := newOptions(opts)
+	mapped := makeChan[U](o)
+	go func() {
+		defer close(mapped)
+		state := initial
+		for t := range receiveAll(o.ctx, channel) {
+			var u U
+			state, u = f(state, t)
+			if !send(o.ctx, mapped, u) {
+				return
+			}
+		}
+	}()
+	return mapped
+}
+
+// ReduceCtx is like Reduce but stops once ctx is done, returning the result
+// reduced so far along with ctx.Err().
+func ReduceCtx[T any](ctx context.Context, channel <-chan T, op func(t1, t2 T) T, initial T) (T, error) {
+	result := initial
+	for {
+		t, ok := receive(ctx, channel)
+		if !ok {
+			return result, ctx.Err()
+		}
+		result = op(result, t)
+	}
 }
 
-func Sum[M Monad](elements chan M) M {
-	var identity M
-	return Reduce(elements, func(a, b M) M { return a + b }, identity)
+func Sum[M Monad](elements <-chan M) M {
+	var sum M
+	for m := range elements {
+		sum += m
+	}
+	return sum
 }
 
-func JoinErrs(errs chan error) error {
+func JoinErrs(errs <-chan error) error {
 	return Reduce(errs, func(e1, e2 error) error { return errors.Join(e1, e2) }, nil)
 }
 
-func Join[T ~string](strings chan T, sep T) T {
+func Join[T ~string](strings <-chan T, sep T) T {
 	first, ok := <-strings
 	if !ok {
 		return first
@@ -93,81 +182,90 @@ func Join[T ~string](strings chan T, sep T) T {
 	return first + Reduce(strings, func(a, b T) T { return a + sep + b }, "")
 }
 
-type Pair[T1, T2 any] struct {
-	Fst T1
-	Snd T2
-}
-
-func Zip[T, U any](chan1 chan T, chan2 chan U) chan Pair[T, U] {
-	zipped := make(chan Pair[T, U])
+// Zip pairs up the values of chan1 and chan2, stopping as soon as either is
+// closed. The rest of the other channel is left unread: pass WithContext to
+// Zip and to the stages producing chan1 and chan2, and cancel it, to release
+// them.
+func Zip[T, U any](chan1 <-chan T, chan2 <-chan U, opts ...Option) <-chan tuple.Pair[T, U] {
+	o := newOptions(opts)
+	zipped := makeChan[tuple.Pair[T, U]](o)
 	go func() {
-		t, ok1 := <-chan1
-		u, ok2 := <-chan2
-		for ok1 && ok2 {
-			zipped <- Pair[T, U]{Fst: t, Snd: u}
-			t, ok1 = <-chan1
-			u, ok2 = <-chan2
-		}
-		close(zipped)
+		defer close(zipped)
+		for {
+			t, ok := receive(o.ctx, chan1)
+			if !ok {
+				return
+			}
+			u, ok := receive(o.ctx, chan2)
+			if !ok || !send(o.ctx, zipped, tuple.Pair[T, U]{Fst: t, Snd: u}) {
+				return
+			}
+		}
 	}()
 	return zipped
 }
 
-func UnZip[T, U any](channel chan Pair[T, U]) (chan T, chan U) {
-	ts := make(chan T)
-	us := make(chan U)
-	go func() {
-		clones := Clone(channel, 2)
-		go func() {
-			for p := range clones[0] {
-				ts <- p.Fst
-			}
-			close(ts)
-		}()
-		go func() {
-			for p := range clones[1] {
-				us <- p.Snd
-			}
-			close(us)
-		}()
-	}()
-	return ts, us
+func UnZip[T, U any](channel <-chan tuple.Pair[T, U], opts ...Option) (<-chan T, <-chan U) {
+	clones := Clone(channel, 2, opts...)
+	return Map(clones[0], tuple.First[T, U], opts...), Map(clones[1], tuple.Second[T, U], opts...)
 }
 
-func Sorted[T constraints.Ordered](channel chan T) chan T {
-	ordered := make(chan T)
+func Sorted[T constraints.Ordered](channel <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	ordered := makeChan[T](o)
 	go func() {
+		defer close(ordered)
 		var buf []T
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
 			buf = append(buf, t)
 		}
 		sort.Slice(buf, func(i, j int) bool {
 			return buf[i] < buf[j]
 		})
 		for _, t := range buf {
-			ordered <- t
+			if !send(o.ctx, ordered, t) {
+				return
+			}
 		}
-		close(ordered)
 	}()
 	return ordered
 }
 
-func Distinct[T comparable](channel chan T) chan T {
-	distinct := make(chan T)
+func Distinct[T comparable](channel <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	distinct := makeChan[T](o)
 	go func() {
+		defer close(distinct)
 		set := make(map[T]struct{})
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
 			if _, ok := set[t]; !ok {
 				set[t] = struct{}{}
-				distinct <- t
+				if !send(o.ctx, distinct, t) {
+					return
+				}
 			}
 		}
-		close(distinct)
 	}()
 	return distinct
 }
 
-func FromSlice[T any](slice []T) chan T {
+// Buffered forwards the values of channel through a channel with a buffer of
+// size n, decoupling the producer from the consumer.
+func Buffered[T any](channel <-chan T, n int, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	buffered := make(chan T, n)
+	go func() {
+		defer close(buffered)
+		for t := range receiveAll(o.ctx, channel) {
+			if !send(o.ctx, buffered, t) {
+				return
+			}
+		}
+	}()
+	return buffered
+}
+
+func FromSlice[T any](slice []T) <-chan T {
 	channel := make(chan T, len(slice))
 	for _, t := range slice {
 		channel <- t
@@ -176,15 +274,67 @@ func FromSlice[T any](slice []T) chan T {
 	return channel
 }
 
-func ToSlice[T any](channel chan T) []T {
-	var slice []T
+func ToSlice[T any](channel <-chan T) []T {
+	return ToSliceInto(channel, nil)
+}
+
+// ToSliceInto appends the values of channel to buf and returns the extended
+// slice. Passing buf[:0] reuses its capacity across calls.
+func ToSliceInto[T any](channel <-chan T, buf []T) []T {
 	for t := range channel {
+		buf = append(buf, t)
+	}
+	return buf
+}
+
+func FromMap[K comparable, V any](m map[K]V) <-chan tuple.Pair[K, V] {
+	channel := make(chan tuple.Pair[K, V], len(m))
+	for k, v := range m {
+		channel <- tuple.Pair[K, V]{Fst: k, Snd: v}
+	}
+	close(channel)
+	return channel
+}
+
+// ToMap collects channel into a map. When a key occurs more than once, resolve
+// is called with the key, the value already in the map, and the incoming value
+// to determine the value to keep, such as maps.KeepFirst. A nil resolve keeps
+// the last value.
+func ToMap[K comparable, V any](channel <-chan tuple.Pair[K, V], resolve func(k K, existing, incoming V) V) map[K]V {
+	return CollectInto(channel, make(map[K]V), resolve)
+}
+
+// CollectInto is like ToMap but adds the pairs of channel to m, which is
+// allocated if nil, and returns it. Keys already in m are resolved against
+// incoming values just like repeated keys.
+func CollectInto[K comparable, V any](channel <-chan tuple.Pair[K, V], m map[K]V, resolve func(k K, existing, incoming V) V) map[K]V {
+	if m == nil {
+		m = make(map[K]V)
+	}
+	for p := range channel {
+		if existing, ok := m[p.Fst]; ok && resolve != nil {
+			m[p.Fst] = resolve(p.Fst, existing, p.Snd)
+		} else {
+			m[p.Fst] = p.Snd
+		}
+	}
+	return m
+}
+
+// ToSliceCtx is like ToSlice but stops once ctx is done, returning the values
+// collected so far along with ctx.Err().
+func ToSliceCtx[T any](ctx context.Context, channel <-chan T) ([]T, error) {
+	var slice []T
+	for {
+		t, ok := receive(ctx, channel)
+		if !ok {
+			return slice, ctx.Err()
+		}
 		slice = append(slice, t)
 	}
-	return slice
 }
 
-func Generate[T any](supplier func() T) (chan T, func()) {
+func Generate[T any](supplier func() T) (<-chan T, func()) {
 	c := make(chan T)
 	keepGoing := atomic.Bool{}
 	keepGoing.Store(true)
@@ -203,148 +353,387 @@ func Generate[T any](supplier func() T) (chan T, func()) {
 	return c, closeFunc
 }
 
-func Iterate[T any](seed T, hasNext func(T) bool, next func(T) T) chan T {
+// GenerateCtx is like Generate but stops when ctx is done. Every value that is
+// produced by supplier is delivered unless ctx is done first, in which case the
+// channel is closed without the producing goroutine blocking.
+func GenerateCtx[T any](ctx context.Context, supplier func() T) <-chan T {
 	c := make(chan T)
 	go func() {
+		defer close(c)
+		for ctx.Err() == nil && send(ctx, c, supplier()) {
+		}
+	}()
+	return c
+}
+
+func Iterate[T any](seed T, hasNext func(T) bool, next func(T) T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
+	go func() {
+		defer close(c)
 		for cur := seed; hasNext(cur); cur = next(cur) {
-			c <- cur
+			if !send(o.ctx, c, cur) {
+				return
+			}
 		}
-		close(c)
 	}()
 	return c
 }
 
-func Range[T constraints.Integer](startInclusive, endExclusive T) chan T {
-	return Iterate(startInclusive, func(t T) bool { return t < endExclusive }, func(t T) T { t++; return t })
+func Range[T constraints.Integer](startInclusive, endExclusive T, opts ...Option) <-chan T {
+	return Iterate(startInclusive, func(t T) bool { return t < endExclusive }, func(t T) T { t++; return t }, opts...)
 }
 
-func RangeClosed[T constraints.Integer](startInclusive, endInclusive T) chan T {
-	return Iterate(startInclusive, func(t T) bool { return t <= endInclusive }, func(t T) T { t++; return t })
+func RangeClosed[T constraints.Integer](startInclusive, endInclusive T, opts ...Option) <-chan T {
+	return Iterate(startInclusive, func(t T) bool { return t <= endInclusive }, func(t T) T { t++; return t }, opts...)
 }
 
-func Limit[T any](channel chan T, max int64) chan T {
-	c := make(chan T)
+// Limit forwards the first max values of channel and then stops receiving
+// from it. Since channel may never end, it is not drained: pass WithContext to
+// Limit and to the stages producing channel, and cancel it, to release them.
+func Limit[T any](channel <-chan T, max int64, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
 	go func() {
-		count := int64(0)
-		for t := range channel {
-			if count < max {
-				c <- t
-				count++
-			} else {
-				break
+		defer close(c)
+		for count := int64(0); count < max; count++ {
+			t, ok := receive(o.ctx, channel)
+			if !ok || !send(o.ctx, c, t) {
+				return
 			}
 		}
-		close(c)
 	}()
 	return c
 }
 
-func Skip[T any](channel chan T, n int64) chan T {
-	c := make(chan T)
+func Skip[T any](channel <-chan T, n int64, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
 	go func() {
+		defer close(c)
 		count := int64(0)
-		for t := range channel {
-			if count >= n {
-				c <- t
+		for t := range receiveAll(o.ctx, channel) {
+			if count >= n && !send(o.ctx, c, t) {
+				return
 			}
 			count++
 		}
-		close(c)
 	}()
 	return c
 }
 
-func AllMatch[T any](channel chan T, p func(T) bool) bool {
-	return Reduce(Map(channel, p), func(t1, t2 bool) bool { return t1 && t2 }, true)
+// AllMatch reports whether p holds for every value of channel. It stops
+// receiving as soon as p fails, see AnyMatch.
+func AllMatch[T any](channel <-chan T, p func(T) bool, opts ...Option) bool {
+	return !AnyMatch(channel, func(t T) bool { return !p(t) }, opts...)
 }
 
-func AnyMatch[T any](channel chan T, p func(T) bool) bool {
-	return Reduce(Map(channel, p), func(t1, t2 bool) bool { return t1 || t2 }, false)
+// AnyMatch reports whether p holds for some value of channel. It stops
+// receiving as soon as p holds or the context given by WithContext is done, in
+// which case only the values received so far are considered. Like Limit, it
+// leaves the rest of channel unread, so pass the same context to the stages
+// producing channel and cancel it to release them.
+func AnyMatch[T any](channel <-chan T, p func(T) bool, opts ...Option) bool {
+	o := newOptions(opts)
+	for t := range receiveAll(o.ctx, channel) {
+		if p(t) {
+			return true
+		}
+	}
+	return false
 }
 
-func TakeWhile[T any](chanel chan T, p func(T) bool) chan T {
-	c := make(chan T)
+// NoneMatch reports whether p holds for no value of channel. It stops
+// receiving as soon as p holds, see AnyMatch.
+func NoneMatch[T any](channel <-chan T, p func(T) bool, opts ...Option) bool {
+	return !AnyMatch(channel, p, opts...)
+}
+
+// TakeWhile forwards the values of channel for as long as p holds and then
+// stops receiving from it. Like Limit, it leaves the rest of channel unread.
+func TakeWhile[T any](channel <-chan T, p func(T) bool, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
 	go func() {
-		for t := range chanel {
-			if p(t) {
-				c <- t
-			} else {
-				break
+		defer close(c)
+		for t := range receiveAll(o.ctx, channel) {
+			if !p(t) || !send(o.ctx, c, t) {
+				return
 			}
 		}
-		close(c)
 	}()
 	return c
 }
 
-func Count[T any](channel chan T) int64 {
-	return Sum(Map(channel, func(t T) int64 { return 1 }))
+// TakeUntilSignal forwards values from channel until stop is closed, and then
+// stops receiving from it. channel is typically never ending, such as one from
+// Generate, so it is not drained: stop its producer separately, for example
+// with the cancel func returned by Generate.
+func TakeUntilSignal[T any](channel <-chan T, stop <-chan struct{}, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
+	go func() {
+		defer close(c)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-o.ctx.Done():
+				return
+			case t, ok := <-channel:
+				if !ok {
+					return
+				}
+				select {
+				case c <- t:
+				case <-stop:
+					return
+				case <-o.ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return c
 }
 
-func Concat[T any](chan1, chan2 chan T) chan T {
-	c := make(chan T)
-	go func() {
-		for t := range chan1 {
-			c <- t
+func Count[T any](channel <-chan T) int64 {
+	var count int64
+	for range channel {
+		count++
+	}
+	return count
+}
+
+// CountAtMost is like Count but stops receiving once max values have been
+// counted or the context given by WithContext is done. Like Limit, it leaves
+// the rest of channel unread, so pass the same context to the stages producing
+// channel and cancel it to release them.
+func CountAtMost[T any](channel <-chan T, max int64, opts ...Option) int64 {
+	o := newOptions(opts)
+	var count int64
+	if max <= 0 {
+		return count
+	}
+	for range receiveAll(o.ctx, channel) {
+		count++
+		if count == max {
+			break
 		}
-		for t := range chan2 {
-			c <- t
+	}
+	return count
+}
+
+// CountCtx is like Count but stops once ctx is done, returning the number of
+// values counted so far along with ctx.Err().
+func CountCtx[T any](ctx context.Context, channel <-chan T) (int64, error) {
+	var count int64
+	for {
+		if _, ok := receive(ctx, channel); !ok {
+			return count, ctx.Err()
+		}
+		count++
+	}
+}
+
+func Concat[T any](chan1, chan2 <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
+	go func() {
+		defer close(c)
+		for _, channel := range []<-chan T{chan1, chan2} {
+			for t := range receiveAll(o.ctx, channel) {
+				if !send(o.ctx, c, t) {
+					return
+				}
+			}
 		}
-		close(c)
 	}()
 	return c
 }
 
-func Peek[T any](channel chan T, consumer func(T)) chan T {
-	c := make(chan T)
+func Peek[T any](channel <-chan T, consumer func(T), opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
 	go func() {
-		for t := range channel {
+		defer close(c)
+		for t := range receiveAll(o.ctx, channel) {
 			consumer(t)
-			c <- t
+			if !send(o.ctx, c, t) {
+				return
+			}
 		}
-		close(c)
 	}()
 	return c
 }
 
-func ForEach[T any](channel chan T, consumer func(T)) {
+// PeekEvery is like Peek but only calls consumer for every nth value, starting
+// with the first. An n less than 1 is treated as 1.
+func PeekEvery[T any](channel <-chan T, n int, consumer func(T), opts ...Option) <-chan T {
+	n = max(n, 1)
+	count := 0
+	return Peek(channel, func(t T) {
+		if count%n == 0 {
+			consumer(t)
+		}
+		count++
+	}, opts...)
+}
+
+// PeekSampled is like Peek but only calls consumer for a random sample of the
+// values, each value being sampled with probability rate.
+func PeekSampled[T any](channel <-chan T, rate float64, consumer func(T), opts ...Option) <-chan T {
+	return Peek(channel, func(t T) {
+		if rand.Float64() < rate {
+			consumer(t)
+		}
+	}, opts...)
+}
+
+// Drain discards the remaining values of channel so that its producer is not
+// left blocked on a send, returning once channel is closed.
+func Drain[T any](channel <-chan T) {
+	for range channel {
+	}
+}
+
+// DrainN discards up to n values of channel and returns the number of values
+// discarded, which is less than n only if channel was closed.
+func DrainN[T any](channel <-chan T, n int64) int64 {
+	var count int64
+	for ; count < n; count++ {
+		if _, ok := <-channel; !ok {
+			break
+		}
+	}
+	return count
+}
+
+// DrainUntil is like Drain but gives up once ctx is done, in which case it
+// returns ctx.Err().
+func DrainUntil[T any](ctx context.Context, channel <-chan T) error {
+	for {
+		if _, ok := receive(ctx, channel); !ok {
+			return ctx.Err()
+		}
+	}
+}
+
+// CloseAll closes every one of channels. Unlike the rest of the package it
+// takes bidirectional channels, since closing is reserved for their owner.
+func CloseAll[T any](channels ...chan T) {
+	for _, channel := range channels {
+		close(channel)
+	}
+}
+
+func ForEach[T any](channel <-chan T, consumer func(T)) {
 	for t := range channel {
 		consumer(t)
 	}
 }
 
-func Of[T any](ts ...T) chan T {
+// ForEachIndexed is like ForEach but also passes consumer the position of each
+// value, starting at 0.
+func ForEachIndexed[T any](channel <-chan T, consumer func(int, T)) {
+	i := 0
+	for t := range channel {
+		consumer(i, t)
+		i++
+	}
+}
+
+// ForEachWhile calls consumer for every value of channel until consumer
+// returns false or the context given by WithContext is done. Like Limit, it
+// then stops receiving and leaves the rest of channel unread, so pass the same
+// context to the stages producing channel and cancel it to release them.
+func ForEachWhile[T any](channel <-chan T, consumer func(T) bool, opts ...Option) {
+	o := newOptions(opts)
+	for t := range receiveAll(o.ctx, channel) {
+		if !consumer(t) {
+			return
+		}
+	}
+}
+
+func Of[T any](ts ...T) <-chan T {
 	return FromSlice(ts)
 }
 
-func Partition[T any](channel chan T, size int) chan chan T {
+func Partition[T any](channel <-chan T, size int, opts ...Option) <-chan <-chan T {
 	// TODO: Rewrite this function as it has unintuitive blocking behavior
-	partitioned := make(chan chan T)
+	o := newOptions(opts)
+	partitioned := make(chan (<-chan T))
 	go func() {
+		defer close(partitioned)
 		count := 0
 		partition := make(chan T)
-		for t := range channel {
+		defer func() { close(partition) }()
+		for t := range receiveAll(o.ctx, channel) {
 			if count == size {
-				partitioned <- partition
+				if !send(o.ctx, partitioned, (<-chan T)(partition)) {
+					return
+				}
 				close(partition)
 				partition = make(chan T)
 				count = 0
 			}
 			if count < size {
-				partition <- t
+				if !send(o.ctx, partition, t) {
+					return
+				}
 				count++
 			}
 		}
 		if count > 0 {
-			partitioned <- partition
-			close(partition)
+			send(o.ctx, partitioned, (<-chan T)(partition))
 		}
-		close(partitioned)
 	}()
 	return partitioned
 }
 
-func Clone[T any](channel chan T, numClones int) []chan T {
+// Chunk groups the values of channel into slices of n values. The last chunk
+// may be smaller.
+func Chunk[T any](channel <-chan T, n int, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	chunked := makeChan[[]T](o)
+	go func() {
+		defer close(chunked)
+		chunk := make([]T, 0, n)
+		for t := range receiveAll(o.ctx, channel) {
+			chunk = append(chunk, t)
+			if len(chunk) >= n {
+				if !send(o.ctx, chunked, chunk) {
+					return
+				}
+				chunk = make([]T, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			send(o.ctx, chunked, chunk)
+		}
+	}()
+	return chunked
+}
+
+func FlattenSlices[T any](channel <-chan []T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	flat := makeChan[T](o)
+	go func() {
+		defer close(flat)
+		for slice := range receiveAll(o.ctx, channel) {
+			for _, t := range slice {
+				if !send(o.ctx, flat, t) {
+					return
+				}
+			}
+		}
+	}()
+	return flat
+}
+
+func Clone[T any](channel <-chan T, numClones int, opts ...Option) []<-chan T {
+	o := newOptions(opts)
 	clones := make([]chan T, numClones)
 	for i := 0; i < numClones; i++ {
 		clones[i] = make(chan T)
@@ -360,19 +749,19 @@ func Clone[T any](channel chan T, numClones int) []chan T {
 			orders[i] <- 0
 		}
 		count := uint64(0)
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
 			for i := 0; i < numClones; i++ {
 				waitGroups[i].Add(1)
 				go func(order uint64) {
 					defer waitGroups[i].Done()
 					for {
-						o := <-orders[i]
-						if o == order {
+						next := <-orders[i]
+						if next == order {
 							break
 						}
-						orders[i] <- o
+						orders[i] <- next
 					}
-					clones[i] <- t
+					send(o.ctx, clones[i], t)
 					orders[i] <- order + 1
 				}(count)
 			}
@@ -385,15 +774,58 @@ func Clone[T any](channel chan T, numClones int) []chan T {
 			}()
 		}
 	}()
-	return clones
+	receiveOnly := make([]<-chan T, numClones)
+	for i, clone := range clones {
+		receiveOnly[i] = clone
+	}
+	return receiveOnly
 }
 
-func Stream[T any](seq iter.Seq[T]) chan T {
-	c := make(chan T)
+func Stream[T any](seq iter.Seq[T], opts ...Option) <-chan T {
+	o := newOptions(opts)
+	c := makeChan[T](o)
 	go func() {
+		defer close(c)
 		for t := range seq {
-			c <- t
+			if !send(o.ctx, c, t) {
+				return
+			}
 		}
 	}()
 	return c
 }
+
+// receive receives a value from channel unless ctx is done first. It reports
+// false if channel is closed or ctx is done.
+func receive[T any](ctx context.Context, channel <-chan T) (T, bool) {
+	select {
+	case t, ok := <-channel:
+		return t, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// receiveAll yields the values of channel until it is closed or ctx is done.
+func receiveAll[T any](ctx context.Context, channel <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			t, ok := receive(ctx, channel)
+			if !ok || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// send sends t on channel unless ctx is done first, reporting whether t was
+// sent.
+func send[T any](ctx context.Context, channel chan T, t T) bool {
+	select {
+	case channel <- t:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
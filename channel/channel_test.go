@@ -1,11 +1,19 @@
 package channel
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lock14/functional/functest"
+	"github.com/lock14/functional/maps"
+	"github.com/lock14/functional/option"
+	"github.com/lock14/functional/tuple"
 	"strconv"
-	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestMap(t *testing.T) {
@@ -69,13 +77,13 @@ func TestFlatMap(t *testing.T) {
 	cases := []struct {
 		name        string
 		input       []int
-		mappingFunc func(int) chan string
+		mappingFunc func(int) <-chan string
 		want        []string
 	}{
 		{
 			name:  "map_empty",
 			input: []int{},
-			mappingFunc: func(i int) chan string {
+			mappingFunc: func(i int) <-chan string {
 				t.Error("mapping function was called when it should not have been")
 				return nil
 			},
@@ -84,7 +92,7 @@ func TestFlatMap(t *testing.T) {
 		{
 			name:  "map_one",
 			input: []int{1},
-			mappingFunc: func(n int) chan string {
+			mappingFunc: func(n int) <-chan string {
 				c := make(chan string)
 				go func() {
 					for i := 0; i <= n; i++ {
@@ -99,7 +107,7 @@ func TestFlatMap(t *testing.T) {
 		{
 			name:  "map_many",
 			input: []int{1, 2, 3},
-			mappingFunc: func(n int) chan string {
+			mappingFunc: func(n int) <-chan string {
 				c := make(chan string)
 				go func() {
 					for i := 0; i <= n; i++ {
@@ -203,6 +211,68 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterMap(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	}
+	cases := []struct {
+		name  string
+		input []string
+		want  []int
+	}{
+		{
+			name:  "filter_map_empty",
+			input: []string{},
+			want:  nil,
+		},
+		{
+			name:  "filter_map_many",
+			input: []string{"1", "x", "3", "", "5"},
+			want:  []int{1, 3, 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ToSlice(FilterMap(FromSlice(tc.input), parse))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			got = ToSlice(MapOption(FromSlice(tc.input), func(s string) option.Option[int] { return option.FromPair(parse(s)) }))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapTry(t *testing.T) {
+	t.Parallel()
+
+	var values []int
+	var panicErr *PanicError
+	for r := range MapTry(Of(1, 0, 2), func(i int) int { return 100 / i }) {
+		if v, err := r.Unwrap(); err != nil {
+			if !errors.As(err, &panicErr) {
+				t.Errorf("expected a *PanicError but got %T", err)
+			}
+		} else {
+			values = append(values, v)
+		}
+	}
+	if diff := cmp.Diff(values, []int{100, 50}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if panicErr == nil {
+		t.Error("expected the panic to be sent as an error")
+	}
+}
+
 func TestFoldLeft(t *testing.T) {
 	t.Parallel()
 
@@ -377,6 +447,84 @@ func TestReduce(t *testing.T) {
 	}
 }
 
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "scan_empty",
+			input: []int{},
+			want:  nil,
+		},
+		{
+			name:  "scan_one",
+			input: []int{1},
+			want:  []int{1},
+		},
+		{
+			name:  "scan_many",
+			input: []int{1, 2, 3, 4},
+			want:  []int{1, 3, 6, 10},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			scanned := Scan(input, 0, func(s, i int) int { return s + i })
+			got := ToSlice(scanned)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapStateful(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "delta_empty",
+			input: []int{},
+			want:  nil,
+		},
+		{
+			name:  "delta_one",
+			input: []int{5},
+			want:  []int{5},
+		},
+		{
+			name:  "delta_many",
+			input: []int{5, 7, 6, 10},
+			want:  []int{5, 2, -1, 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			deltas := MapStateful(input, 0, func(prev, i int) (int, int) { return i, i - prev })
+			got := ToSlice(deltas)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
 func TestSum(t *testing.T) {
 	t.Parallel()
 
@@ -451,7 +599,7 @@ func TestJoinErrs(t *testing.T) {
 
 			input := FromSlice(tc.input)
 			got := JoinErrs(input)
-			if diff := DiffErr(got, tc.want); diff != "" {
+			if diff := functest.DiffErr(got, tc.want); diff != "" {
 				t.Errorf("unexpected result (-got, +want): %s", diff)
 			}
 			// check that channel is closed now
@@ -517,7 +665,7 @@ func TestZip(t *testing.T) {
 		name       string
 		leftInput  []int
 		rightInput []string
-		want       []Pair[int, string]
+		want       []tuple.Pair[int, string]
 	}{
 		{
 			name:       "both_empty",
@@ -541,7 +689,7 @@ func TestZip(t *testing.T) {
 			name:       "left_shorter",
 			leftInput:  []int{1},
 			rightInput: []string{"bob", "mary", "jane"},
-			want: []Pair[int, string]{
+			want: []tuple.Pair[int, string]{
 				{Fst: 1, Snd: "bob"},
 			},
 		},
@@ -549,7 +697,7 @@ func TestZip(t *testing.T) {
 			name:       "right_shorter",
 			leftInput:  []int{1, 2, 3},
 			rightInput: []string{"bob"},
-			want: []Pair[int, string]{
+			want: []tuple.Pair[int, string]{
 				{Fst: 1, Snd: "bob"},
 			},
 		},
@@ -557,7 +705,7 @@ func TestZip(t *testing.T) {
 			name:       "same_length",
 			leftInput:  []int{1, 2, 3},
 			rightInput: []string{"bob", "mary", "jane"},
-			want: []Pair[int, string]{
+			want: []tuple.Pair[int, string]{
 				{Fst: 1, Snd: "bob"},
 				{Fst: 2, Snd: "mary"},
 				{Fst: 3, Snd: "jane"},
@@ -589,19 +737,19 @@ func TestUnZip(t *testing.T) {
 
 	cases := []struct {
 		name      string
-		input     []Pair[int, string]
+		input     []tuple.Pair[int, string]
 		wantLeft  []int
 		wantRight []string
 	}{
 		{
 			name:      "empty",
-			input:     []Pair[int, string]{},
+			input:     []tuple.Pair[int, string]{},
 			wantLeft:  nil,
 			wantRight: nil,
 		},
 		{
 			name: "one",
-			input: []Pair[int, string]{
+			input: []tuple.Pair[int, string]{
 				{Fst: 1, Snd: "bob"},
 			},
 			wantLeft:  []int{1},
@@ -609,7 +757,7 @@ func TestUnZip(t *testing.T) {
 		},
 		{
 			name: "many",
-			input: []Pair[int, string]{
+			input: []tuple.Pair[int, string]{
 				{Fst: 1, Snd: "bob"},
 				{Fst: 2, Snd: "mary"},
 				{Fst: 3, Snd: "jane"},
@@ -754,6 +902,292 @@ func TestDistinct(t *testing.T) {
 	}
 }
 
+func TestToMap(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		input   []tuple.Pair[string, int]
+		resolve func(string, int, int) int
+		want    map[string]int
+	}{
+		{
+			name:    "empty",
+			input:   []tuple.Pair[string, int]{},
+			resolve: maps.KeepFirst[string, int],
+			want:    map[string]int{},
+		},
+		{
+			name:    "keep_first",
+			input:   []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "b", Snd: 2}, {Fst: "a", Snd: 3}},
+			resolve: maps.KeepFirst[string, int],
+			want:    map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name:    "keep_last",
+			input:   []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "b", Snd: 2}, {Fst: "a", Snd: 3}},
+			resolve: maps.KeepLast[string, int],
+			want:    map[string]int{"a": 3, "b": 2},
+		},
+		{
+			name:    "sum",
+			input:   []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "b", Snd: 2}, {Fst: "a", Snd: 3}},
+			resolve: func(_ string, existing, incoming int) int { return existing + incoming },
+			want:    map[string]int{"a": 4, "b": 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ToMap(FromSlice(tc.input), tc.resolve)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			// round trip through FromMap
+			roundTrip := ToMap(FromMap(got), nil)
+			if diff := cmp.Diff(roundTrip, tc.want); diff != "" {
+				t.Errorf("unexpected round trip result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToSliceInto(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		buf   []int
+		input []int
+		want  []int
+	}{
+		{
+			name:  "nil_buffer",
+			buf:   nil,
+			input: []int{1, 2},
+			want:  []int{1, 2},
+		},
+		{
+			name:  "appends_to_buffer",
+			buf:   []int{1},
+			input: []int{2, 3},
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "empty_input",
+			buf:   []int{1},
+			input: []int{},
+			want:  []int{1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ToSliceInto(FromSlice(tc.input), tc.buf)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToSliceIntoReusesCapacity(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]int, 0, 8)
+	got := ToSliceInto(FromSlice([]int{1, 2, 3}), buf)
+	if &got[0] != &buf[:1][0] {
+		t.Error("expected ToSliceInto to reuse the capacity of buf")
+	}
+}
+
+func TestCollectInto(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		m       map[string]int
+		input   []tuple.Pair[string, int]
+		resolve func(string, int, int) int
+		want    map[string]int
+	}{
+		{
+			name:    "nil_map",
+			m:       nil,
+			input:   []tuple.Pair[string, int]{{Fst: "a", Snd: 1}},
+			resolve: nil,
+			want:    map[string]int{"a": 1},
+		},
+		{
+			name:    "existing_keys_resolved",
+			m:       map[string]int{"a": 1, "b": 2},
+			input:   []tuple.Pair[string, int]{{Fst: "a", Snd: 3}, {Fst: "c", Snd: 4}},
+			resolve: func(_ string, existing, incoming int) int { return existing + incoming },
+			want:    map[string]int{"a": 4, "b": 2, "c": 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := CollectInto(FromSlice(tc.input), tc.m, tc.resolve)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		size  int
+		want  [][]int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			size:  2,
+			want:  nil,
+		},
+		{
+			name:  "exact",
+			input: []int{1, 2, 3, 4},
+			size:  2,
+			want:  [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:  "remainder",
+			input: []int{1, 2, 3, 4, 5},
+			size:  2,
+			want:  [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ToSlice(Chunk(FromSlice(tc.input), tc.size))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			// flattening the chunks gives back the input
+			flat := ToSlice(FlattenSlices(FromSlice(got)))
+			if diff := cmp.Diff(flat, tc.input, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected flattened result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		input     []int
+		n         int64
+		wantCount int64
+		wantRest  []int
+	}{
+		{
+			name:      "drain_empty",
+			input:     []int{},
+			n:         2,
+			wantCount: 0,
+			wantRest:  nil,
+		},
+		{
+			name:      "drain_some",
+			input:     []int{1, 2, 3},
+			n:         2,
+			wantCount: 2,
+			wantRest:  []int{3},
+		},
+		{
+			name:      "drain_more_than_size",
+			input:     []int{1, 2, 3},
+			n:         5,
+			wantCount: 3,
+			wantRest:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			if got := DrainN(input, tc.n); got != tc.wantCount {
+				t.Errorf("unexpected count: got %d, want %d", got, tc.wantCount)
+			}
+			if diff := cmp.Diff(ToSlice(input), tc.wantRest); diff != "" {
+				t.Errorf("unexpected rest (-got, +want): %s", diff)
+			}
+			if err := DrainUntil(context.Background(), FromSlice(tc.input)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCtxTerminals(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		got, err := ToSliceCtx(ctx, FromSlice([]int{1, 2, 3}))
+		if diff := cmp.Diff(got, []int{1, 2, 3}); diff != "" || err != nil {
+			t.Errorf("unexpected result (-got, +want): %s, err: %v", diff, err)
+		}
+		count, err := CountCtx(ctx, FromSlice([]int{1, 2, 3}))
+		if count != 3 || err != nil {
+			t.Errorf("unexpected count: got (%d, %v), want (3, <nil>)", count, err)
+		}
+		sum, err := ReduceCtx(ctx, FromSlice([]int{1, 2, 3}), func(a, b int) int { return a + b }, 0)
+		if sum != 6 || err != nil {
+			t.Errorf("unexpected sum: got (%d, %v), want (6, <nil>)", sum, err)
+		}
+	})
+
+	t.Run("stalled", func(t *testing.T) {
+		t.Parallel()
+
+		// the producer sends three values and then stalls forever
+		stalled := func() <-chan int {
+			c := make(chan int)
+			go func() {
+				for i := 1; i <= 3; i++ {
+					c <- i
+				}
+			}()
+			return c
+		}
+		timeout := func() context.Context {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			t.Cleanup(cancel)
+			return ctx
+		}
+		got, err := ToSliceCtx(timeout(), stalled())
+		if diff := cmp.Diff(got, []int{1, 2, 3}); diff != "" || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("unexpected result (-got, +want): %s, err: %v", diff, err)
+		}
+		count, err := CountCtx(timeout(), stalled())
+		if count != 3 || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("unexpected count: got (%d, %v), want (3, %v)", count, err, context.DeadlineExceeded)
+		}
+	})
+}
+
 type StatefulSupplier struct {
 	state int
 }
@@ -826,6 +1260,239 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestTakeUntilSignal(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		numReads int
+		want     []int
+	}{
+		{
+			name:     "stop_immediately",
+			numReads: 0,
+			want:     nil,
+		},
+		{
+			name:     "stop_after_many",
+			numReads: 5,
+			want:     []int{0, 1, 2, 3, 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int64
+			generator, cancel := Generate(func() int { return int(calls.Add(1) - 1) })
+			defer cancel()
+			stop := make(chan struct{})
+			taken := TakeUntilSignal(generator, stop)
+			var got []int
+			for i := 0; i < tc.numReads; i++ {
+				got = append(got, <-taken)
+			}
+			close(stop)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			// check that the output channel is closed now
+			for range taken {
+			}
+			_, ok := <-taken
+			if ok {
+				t.Error("expected taken to be closed ")
+			}
+			// the generator is no longer read, so once its pending value has
+			// been produced it stops calling supplier
+			time.Sleep(10 * time.Millisecond)
+			before := calls.Load()
+			time.Sleep(10 * time.Millisecond)
+			if after := calls.Load(); after != before {
+				t.Errorf("supplier called %d times after the signal", after-before)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(i int) bool { return i%2 == 0 }
+	cases := []struct {
+		name     string
+		input    []int
+		wantAll  bool
+		wantAny  bool
+		wantNone bool
+	}{
+		{
+			name:     "empty",
+			input:    []int{},
+			wantAll:  true,
+			wantAny:  false,
+			wantNone: true,
+		},
+		{
+			name:     "all",
+			input:    []int{2, 4, 6},
+			wantAll:  true,
+			wantAny:  true,
+			wantNone: false,
+		},
+		{
+			name:     "some",
+			input:    []int{1, 2, 3},
+			wantAll:  false,
+			wantAny:  true,
+			wantNone: false,
+		},
+		{
+			name:     "none",
+			input:    []int{1, 3, 5},
+			wantAll:  false,
+			wantAny:  false,
+			wantNone: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := AllMatch(FromSlice(tc.input), isEven); got != tc.wantAll {
+				t.Errorf("unexpected AllMatch result: got %v, want %v", got, tc.wantAll)
+			}
+			if got := AnyMatch(FromSlice(tc.input), isEven); got != tc.wantAny {
+				t.Errorf("unexpected AnyMatch result: got %v, want %v", got, tc.wantAny)
+			}
+			if got := NoneMatch(FromSlice(tc.input), isEven); got != tc.wantNone {
+				t.Errorf("unexpected NoneMatch result: got %v, want %v", got, tc.wantNone)
+			}
+		})
+	}
+}
+
+func TestAnyMatchShortCircuits(t *testing.T) {
+	t.Parallel()
+
+	generator, cancel := Generate((&StatefulSupplier{}).Supply)
+	defer cancel()
+	if !AnyMatch(generator, func(i int) bool { return i == 10 }) {
+		t.Error("expected AnyMatch to find a match in an infinite channel")
+	}
+}
+
+func TestCountAtMost(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		max   int64
+		want  int64
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			max:   3,
+			want:  0,
+		},
+		{
+			name:  "fewer_than_max",
+			input: []int{1, 2},
+			max:   3,
+			want:  2,
+		},
+		{
+			name:  "more_than_max",
+			input: []int{1, 2, 3, 4, 5},
+			max:   3,
+			want:  3,
+		},
+		{
+			name:  "zero_max",
+			input: []int{1, 2, 3},
+			max:   0,
+			want:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := CountAtMost(FromSlice(tc.input), tc.max); got != tc.want {
+				t.Errorf("unexpected result: got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCountAtMostInfinite(t *testing.T) {
+	t.Parallel()
+
+	generator, cancel := Generate((&StatefulSupplier{}).Supply)
+	defer cancel()
+	if got := CountAtMost(generator, 5); got != 5 {
+		t.Errorf("unexpected result: got %d, want 5", got)
+	}
+}
+
+func TestCountAtMostCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := CountAtMost(make(chan int), 5, WithContext(ctx)); got != 0 {
+		t.Errorf("unexpected result: got %d, want 0", got)
+	}
+}
+
+func TestGenerateCtx(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		numReads int
+		want     []int
+	}{
+		{
+			name:     "read_none",
+			numReads: 0,
+			want:     nil,
+		},
+		{
+			name:     "read_many",
+			numReads: 10,
+			want:     []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			supplier := &StatefulSupplier{}
+			generator := GenerateCtx(ctx, supplier.Supply)
+			var got []int
+			for i := 0; i < tc.numReads; i++ {
+				got = append(got, <-generator)
+			}
+			cancel()
+			// wait for the generator to shut down
+			for range generator {
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			// at most one value can be produced that was never delivered
+			if diff := supplier.NumCalls() - tc.numReads; diff < 0 || diff > 1 {
+				t.Errorf("unexpected number of calls: %d", diff)
+			}
+		})
+	}
+}
+
 type StatefulConsumer[T any] struct {
 	consumed []T
 }
@@ -899,32 +1566,169 @@ func TestPeek(t *testing.T) {
 	}
 }
 
-func DiffErr(got error, want error) string {
-	if got == nil && want == nil {
-		return ""
+func TestForEachIndexed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{
+			name:  "empty",
+			input: []string{},
+			want:  nil,
+		},
+		{
+			name:  "many",
+			input: []string{"a", "b", "c"},
+			want:  []string{"0:a", "1:b", "2:c"},
+		},
 	}
-	if got == nil {
-		return fmt.Sprintf("got error <nil> but want an error containing %q", want)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			ForEachIndexed(FromSlice(tc.input), func(i int, s string) {
+				got = append(got, fmt.Sprintf("%d:%s", i, s))
+			})
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
 	}
-	if want == nil {
-		return fmt.Sprintf("got error %q but want an error <nil>", got)
+}
+
+func TestForEachWhile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			want:  nil,
+		},
+		{
+			name:  "never_stops",
+			input: []int{1, 2, 3},
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "stops_early",
+			input: []int{1, 2, 3, 4, 5},
+			want:  []int{1, 2, 3},
+		},
 	}
-	if gotMsg, wantMsg := got.Error(), want.Error(); !strings.Contains(gotMsg, wantMsg) {
-		out := fmt.Sprintf("got error %q but want an error containing %q", gotMsg, want)
 
-		// For long strings that will be hard to visually diff, include a diff.
-		// Explanation of the &&'s and ||'s: if we're diffing a long error
-		// message against a short one, a detailed diff isn't needed. The
-		// difference will be obvious to the eye, and any extra message will
-		// just be clutter. So only show the extra diff if the messages are both
-		// long, or both multi-line.
-		const msgLen = 20 // chosen arbitrarily
-		bothAreLong := len(wantMsg) >= msgLen && len(gotMsg) >= msgLen
-		bothAreMultiline := strings.Contains(wantMsg, "\n") && strings.Contains(gotMsg, "\n")
-		if bothAreLong || bothAreMultiline {
-			out += fmt.Sprintf("; diff was (-got,+want):\n%s", cmp.Diff(gotMsg, want))
-		}
-		return out
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int
+			ForEachWhile(FromSlice(tc.input), func(i int) bool {
+				got = append(got, i)
+				return i < 3
+			})
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPeekEvery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		input        []int
+		n            int
+		want         []int
+		wantConsumed []int
+	}{
+		{
+			name:         "empty",
+			input:        []int{},
+			n:            2,
+			want:         nil,
+			wantConsumed: nil,
+		},
+		{
+			name:         "every_value",
+			input:        []int{1, 2, 3},
+			n:            1,
+			want:         []int{1, 2, 3},
+			wantConsumed: []int{1, 2, 3},
+		},
+		{
+			name:         "every_other_value",
+			input:        []int{1, 2, 3, 4, 5},
+			n:            2,
+			want:         []int{1, 2, 3, 4, 5},
+			wantConsumed: []int{1, 3, 5},
+		},
+		{
+			name:         "non_positive_n",
+			input:        []int{1, 2, 3},
+			n:            0,
+			want:         []int{1, 2, 3},
+			wantConsumed: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			consumer := &StatefulConsumer[int]{}
+			got := ToSlice(PeekEvery(FromSlice(tc.input), tc.n, consumer.Consume))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(consumer.Consumed(), tc.wantConsumed); diff != "" {
+				t.Errorf("unexpected result for consumed (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPeekSampled(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		input        []int
+		rate         float64
+		wantConsumed []int
+	}{
+		{
+			name:         "never",
+			input:        []int{1, 2, 3},
+			rate:         0,
+			wantConsumed: nil,
+		},
+		{
+			name:         "always",
+			input:        []int{1, 2, 3},
+			rate:         1,
+			wantConsumed: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			consumer := &StatefulConsumer[int]{}
+			got := ToSlice(PeekSampled(FromSlice(tc.input), tc.rate, consumer.Consume))
+			if diff := cmp.Diff(got, tc.input); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(consumer.Consumed(), tc.wantConsumed); diff != "" {
+				t.Errorf("unexpected result for consumed (-got, +want): %s", diff)
+			}
+		})
 	}
-	return ""
 }
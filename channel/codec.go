@@ -0,0 +1,86 @@
+package channel
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Encoder writes values to a stream, like *gob.Encoder and *json.Encoder.
+type Encoder interface {
+	Encode(v any) error
+}
+
+// Decoder reads values from a stream, like *gob.Decoder and *json.Decoder. It
+// returns io.EOF once the stream is exhausted.
+type Decoder interface {
+	Decode(v any) error
+}
+
+// Codec determines how EncodeTo and DecodeFrom represent values in a stream.
+type Codec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+var (
+	// Gob encodes values with encoding/gob.
+	Gob Codec = gobCodec{}
+	// JSONLines encodes every value as a line of JSON.
+	JSONLines Codec = jsonLinesCodec{}
+)
+
+type gobCodec struct{}
+
+func (gobCodec) NewEncoder(w io.Writer) Encoder { return gob.NewEncoder(w) }
+func (gobCodec) NewDecoder(r io.Reader) Decoder { return gob.NewDecoder(r) }
+
+type jsonLinesCodec struct{}
+
+// json.Encoder terminates every value with a newline, and json.Decoder reads
+// values separated by whitespace, so both already speak JSON Lines.
+func (jsonLinesCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+func (jsonLinesCodec) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+
+// EncodeTo writes every value of channel to w using codec, returning once
+// channel is closed. If writing fails, the error is returned and the rest of
+// channel is left unread, so the caller must cancel the stages producing
+// channel, for example through WithContext, to release them.
+func EncodeTo[T any](w io.Writer, channel <-chan T, codec Codec) error {
+	encoder := codec.NewEncoder(w)
+	for t := range channel {
+		if err := encoder.Encode(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFrom reads values from r using codec until r is exhausted. An error
+// other than reaching the end of r stops decoding and is sent on the returned
+// error channel, which is buffered so it can be checked after the values have
+// been drained.
+func DecodeFrom[T any](r io.Reader, codec Codec, opts ...Option) (<-chan T, <-chan error) {
+	o := newOptions(opts)
+	decoded := makeChan[T](o)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(decoded)
+		decoder := codec.NewDecoder(r)
+		for {
+			var t T
+			if err := decoder.Decode(&t); err != nil {
+				if !errors.Is(err, io.EOF) {
+					errs <- err
+				}
+				return
+			}
+			if !send(o.ctx, decoded, t) {
+				return
+			}
+		}
+	}()
+	return decoded, errs
+}
@@ -0,0 +1,112 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"io"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	Name  string
+	Count int
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		codec Codec
+		input []record
+	}{
+		{
+			name:  "gob_empty",
+			codec: Gob,
+			input: nil,
+		},
+		{
+			name:  "gob",
+			codec: Gob,
+			input: []record{{Name: "a", Count: 1}, {Name: "b", Count: 2}},
+		},
+		{
+			name:  "json_lines_empty",
+			codec: JSONLines,
+			input: nil,
+		},
+		{
+			name:  "json_lines",
+			codec: JSONLines,
+			input: []record{{Name: "a", Count: 1}, {Name: "b", Count: 2}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			// connect the two halves with a pipe, as if they ran in separate
+			// processes
+			r, w := io.Pipe()
+			go func() {
+				w.CloseWithError(EncodeTo(w, FromSlice(tc.input), tc.codec))
+			}()
+			decoded, errs := DecodeFrom[record](r, tc.codec)
+			got := ToSlice(decoded)
+			if diff := cmp.Diff(got, tc.input); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if err := <-errs; err != nil {
+				t.Errorf("got error %v, want %v", err, nil)
+			}
+		})
+	}
+}
+
+func TestJSONLinesFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := EncodeTo(&buf, FromSlice([]record{{Name: "a", Count: 1}, {Name: "b", Count: 2}}), JSONLines); err != nil {
+		t.Fatalf("got error %v, want %v", err, nil)
+	}
+	want := "{\"Name\":\"a\",\"Count\":1}\n{\"Name\":\"b\",\"Count\":2}\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestDecodeFromInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	decoded, errs := DecodeFrom[record](strings.NewReader("{\"Name\":\"a\",\"Count\":1}\nnot json\n"), JSONLines)
+	got := ToSlice(decoded)
+	if diff := cmp.Diff(got, []record{{Name: "a", Count: 1}}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if err := <-errs; err == nil {
+		t.Error("expected an error decoding invalid input")
+	}
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestEncodeToWriteError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := EncodeTo(failingWriter{}, Range(0, 100, WithContext(ctx)), JSONLines)
+	if !errors.Is(err, errWrite) {
+		t.Errorf("got error %v, want %v", err, errWrite)
+	}
+}
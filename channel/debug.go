@@ -0,0 +1,86 @@
+package channel
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// DebugEventKind is the kind of a DebugEvent.
+type DebugEventKind int
+
+const (
+	// Received is reported when a named stage receives a value from upstream.
+	Received DebugEventKind = iota
+	// Sent is reported when the downstream accepts a value from a named stage.
+	Sent
+	// Closed is reported when a named stage closes its channel.
+	Closed
+)
+
+func (k DebugEventKind) String() string {
+	switch k {
+	case Received:
+		return "received"
+	case Sent:
+		return "sent"
+	case Closed:
+		return "closed"
+	default:
+		return fmt.Sprintf("DebugEventKind(%d)", int(k))
+	}
+}
+
+// DebugEvent describes a value flowing through, or the closing of, a stage
+// wrapped with Named. A stage whose last event is Received is blocked on its
+// downstream, while one whose last event is Sent is waiting on its upstream.
+type DebugEvent struct {
+	Stage string
+	Kind  DebugEventKind
+	// Value is the value received or sent. It is nil for Closed events.
+	Value any
+}
+
+func (e DebugEvent) String() string {
+	if e.Kind == Closed {
+		return fmt.Sprintf("[%s] %s", e.Stage, e.Kind)
+	}
+	return fmt.Sprintf("[%s] %s %v", e.Stage, e.Kind, e.Value)
+}
+
+var debugHook atomic.Pointer[func(DebugEvent)]
+
+// SetDebugHook enables debug tracing of the stages wrapped with Named by
+// calling hook for every event, for example with log.Println. Passing nil
+// disables tracing. The hook may be called concurrently from several stages.
+func SetDebugHook(hook func(DebugEvent)) {
+	if hook == nil {
+		debugHook.Store(nil)
+	} else {
+		debugHook.Store(&hook)
+	}
+}
+
+func trace(stage string, kind DebugEventKind, value any) {
+	if hook := debugHook.Load(); hook != nil {
+		(*hook)(DebugEvent{Stage: stage, Kind: kind, Value: value})
+	}
+}
+
+// Named forwards every value of channel unchanged, reporting its flow to the
+// debug hook under the given stage name when tracing is enabled.
+func Named[T any](channel <-chan T, stage string, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	named := makeChan[T](o)
+	go func() {
+		defer close(named)
+		defer trace(stage, Closed, nil)
+		for t := range receiveAll(o.ctx, channel) {
+			trace(stage, Received, t)
+			if !send(o.ctx, named, t) {
+				return
+			}
+			trace(stage, Sent, t)
+		}
+	}()
+	return named
+}
@@ -0,0 +1,33 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"testing"
+)
+
+// TestNamed is not run in parallel since the debug hook is global.
+func TestNamed(t *testing.T) {
+	mu := sync.Mutex{}
+	var got []string
+	SetDebugHook(func(e DebugEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, e.String())
+	})
+	defer SetDebugHook(nil)
+
+	ToSlice(Named(FromSlice([]int{1, 2}), "source"))
+	want := []string{
+		"[source] received 1",
+		"[source] sent 1",
+		"[source] received 2",
+		"[source] sent 2",
+		"[source] closed",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected events (-got, +want): %s", diff)
+	}
+}
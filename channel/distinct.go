@@ -0,0 +1,37 @@
+package channel
+
+import (
+	"container/list"
+)
+
+// DistinctLimited is like Distinct but remembers at most maxEntries values,
+// evicting the least recently seen value once full. This bounds memory on
+// infinite streams at the cost of re-emitting a duplicate whose previous
+// occurrence has already been evicted.
+func DistinctLimited[T comparable](channel <-chan T, maxEntries int, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	distinct := makeChan[T](o)
+	go func() {
+		defer close(distinct)
+		recent := list.New()
+		seen := make(map[T]*list.Element)
+		for t := range receiveAll(o.ctx, channel) {
+			if e, ok := seen[t]; ok {
+				recent.MoveToFront(e)
+				continue
+			}
+			if recent.Len() >= maxEntries {
+				if oldest := recent.Back(); oldest != nil {
+					delete(seen, recent.Remove(oldest).(T))
+				}
+			}
+			if maxEntries > 0 {
+				seen[t] = recent.PushFront(t)
+			}
+			if !send(o.ctx, distinct, t) {
+				return
+			}
+		}
+	}()
+	return distinct
+}
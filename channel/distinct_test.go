@@ -0,0 +1,59 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestDistinctLimited(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		input      []int
+		maxEntries int
+		want       []int
+	}{
+		{
+			name:       "empty",
+			input:      []int{},
+			maxEntries: 2,
+			want:       nil,
+		},
+		{
+			name:       "within_limit",
+			input:      []int{1, 2, 1, 2, 3, 3},
+			maxEntries: 3,
+			want:       []int{1, 2, 3},
+		},
+		{
+			name:       "evicts_least_recently_seen",
+			input:      []int{1, 2, 1, 3, 2, 1},
+			maxEntries: 2,
+			want:       []int{1, 2, 3, 2, 1},
+		},
+		{
+			name:       "no_entries",
+			input:      []int{1, 1, 1},
+			maxEntries: 0,
+			want:       []int{1, 1, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			distinct := DistinctLimited(input, tc.maxEntries)
+			got := ToSlice(distinct)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			_, ok := <-distinct
+			if ok {
+				t.Error("expected distinct to be closed ")
+			}
+		})
+	}
+}
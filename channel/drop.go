@@ -0,0 +1,89 @@
+package channel
+
+// DropNewest buffers up to capacity values of channel while the consumer is
+// busy, discarding incoming values while the buffer is full. The producer is
+// never blocked by a slow consumer. A capacity less than 1 is treated as 1.
+func DropNewest[T any](channel <-chan T, capacity int, opts ...Option) <-chan T {
+	return dropping(channel, capacity, false, newOptions(opts))
+}
+
+// DropOldest buffers up to capacity values of channel while the consumer is
+// busy, discarding the oldest buffered value to make room for an incoming one
+// while the buffer is full. The producer is never blocked by a slow consumer.
+// A capacity less than 1 is treated as 1.
+func DropOldest[T any](channel <-chan T, capacity int, opts ...Option) <-chan T {
+	return dropping(channel, capacity, true, newOptions(opts))
+}
+
+func dropping[T any](channel <-chan T, capacity int, dropOldest bool, o options) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		buf := newRing[T](max(capacity, 1))
+		in := channel
+		for in != nil || buf.len() > 0 {
+			var sendTo chan T
+			var next T
+			if buf.len() > 0 {
+				sendTo, next = out, buf.peek()
+			}
+			select {
+			case t, ok := <-in:
+				if !ok {
+					in = nil
+				} else if !buf.full() {
+					buf.push(t)
+				} else if dropOldest && buf.len() > 0 {
+					buf.pop()
+					buf.push(t)
+				}
+			case sendTo <- next:
+				buf.pop()
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+type ring[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+func newRing[T any](capacity int) *ring[T] {
+	return &ring[T]{buf: make([]T, max(capacity, 0))}
+}
+
+func (r *ring[T]) len() int {
+	return r.size
+}
+
+func (r *ring[T]) full() bool {
+	return r.size == len(r.buf)
+}
+
+func (r *ring[T]) push(t T) {
+	r.buf[(r.head+r.size)%len(r.buf)] = t
+	r.size++
+}
+
+func (r *ring[T]) peek() T {
+	return r.buf[r.head]
+}
+
+func (r *ring[T]) pop() T {
+	var zero T
+	t := r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.size--
+	return t
+}
+
+// at returns the i-th oldest value in the ring.
+func (r *ring[T]) at(i int) T {
+	return r.buf[(r.head+i)%len(r.buf)]
+}
@@ -0,0 +1,94 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestDrop(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    []int
+		capacity int
+		dropFunc func(<-chan int, int, ...Option) <-chan int
+		want     []int
+	}{
+		{
+			name:     "drop_newest_empty",
+			input:    []int{},
+			capacity: 3,
+			dropFunc: DropNewest[int],
+			want:     nil,
+		},
+		{
+			name:     "drop_newest_within_capacity",
+			input:    []int{1, 2},
+			capacity: 3,
+			dropFunc: DropNewest[int],
+			want:     []int{1, 2},
+		},
+		{
+			name:     "drop_newest_over_capacity",
+			input:    []int{1, 2, 3, 4, 5, 6},
+			capacity: 3,
+			dropFunc: DropNewest[int],
+			want:     []int{1, 2, 3},
+		},
+		{
+			name:     "drop_newest_zero_capacity",
+			input:    []int{1, 2, 3},
+			capacity: 0,
+			dropFunc: DropNewest[int],
+			want:     []int{1},
+		},
+		{
+			name:     "drop_oldest_empty",
+			input:    []int{},
+			capacity: 3,
+			dropFunc: DropOldest[int],
+			want:     nil,
+		},
+		{
+			name:     "drop_oldest_within_capacity",
+			input:    []int{1, 2},
+			capacity: 3,
+			dropFunc: DropOldest[int],
+			want:     []int{1, 2},
+		},
+		{
+			name:     "drop_oldest_over_capacity",
+			input:    []int{1, 2, 3, 4, 5, 6},
+			capacity: 3,
+			dropFunc: DropOldest[int],
+			want:     []int{4, 5, 6},
+		},
+		{
+			name:     "drop_oldest_zero_capacity",
+			input:    []int{1, 2, 3},
+			capacity: 0,
+			dropFunc: DropOldest[int],
+			want:     []int{3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := make(chan int)
+			dropping := tc.dropFunc(input, tc.capacity)
+			// nothing is read until the producer is done, so the consumer
+			// is as slow as it can be
+			for _, i := range tc.input {
+				input <- i
+			}
+			close(input)
+			got := ToSlice(dropping)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
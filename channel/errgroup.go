@@ -0,0 +1,33 @@
+package channel
+
+import (
+	"golang.org/x/sync/errgroup"
+	"sync/atomic"
+)
+
+// GoEach schedules f on g for every value of channel, so the number of values
+// handled at once is bounded by the limit of g, see errgroup.Group.SetLimit.
+// It returns once channel is closed, the context given by WithContext is done,
+// or a task has failed, after which f is not called again; g.Wait returns the
+// first error. The rest of channel is left unread, so create g with
+// errgroup.WithContext and pass its context to the stages producing channel to
+// release them once a task fails.
+func GoEach[T any](g *errgroup.Group, channel <-chan T, f func(T) error, opts ...Option) {
+	o := newOptions(opts)
+	var failed atomic.Bool
+	for t := range receiveAll(o.ctx, channel) {
+		if failed.Load() {
+			return
+		}
+		g.Go(func() error {
+			if failed.Load() {
+				return nil
+			}
+			if err := f(t); err != nil {
+				failed.Store(true)
+				return err
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package channel
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/errgroup"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestGoEach(t *testing.T) {
+	t.Parallel()
+
+	var g errgroup.Group
+	g.SetLimit(3)
+	var mu sync.Mutex
+	sum, running, peak := 0, 0, 0
+	GoEach(&g, Range(0, 10), func(i int) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		sum += i
+		mu.Unlock()
+		// give the other tasks a chance to overlap with this one
+		runtime.Gosched()
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		t.Fatalf("got error %v, want %v", err, nil)
+	}
+	if sum != 45 {
+		t.Errorf("unexpected sum: got %d, want 45", sum)
+	}
+	if peak > 3 {
+		t.Errorf("unexpected concurrency: got %d, want at most 3", peak)
+	}
+}
+
+func TestGoEachStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	errThree := errors.New("three")
+	var g errgroup.Group
+	// with a single task at a time the failure is seen before the next value
+	g.SetLimit(1)
+	var mu sync.Mutex
+	var handled []int
+	GoEach(&g, Range(0, 10), func(i int) error {
+		mu.Lock()
+		handled = append(handled, i)
+		mu.Unlock()
+		if i == 3 {
+			return errThree
+		}
+		return nil
+	})
+	if err := g.Wait(); !errors.Is(err, errThree) {
+		t.Errorf("got error %v, want %v", err, errThree)
+	}
+	if diff := cmp.Diff(handled, []int{0, 1, 2, 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -1,42 +1,48 @@
 package channel
 
-func MapWithErr[T, U any](channel chan T, f func(T) (U, error)) (chan U, chan error) {
-	mapped := make(chan U)
-	errs := make(chan error)
+func MapWithErr[T, U any](channel <-chan T, f func(T) (U, error), opts ...Option) (<-chan U, <-chan error) {
+	o := newOptions(opts)
+	mapped := makeChan[U](o)
+	errs := makeChan[error](o)
 	go func() {
-		for t := range channel {
+		defer close(errs)
+		defer close(mapped)
+		for t := range receiveAll(o.ctx, channel) {
 			u, err := f(t)
 			if err != nil {
-				errs <- err
-			} else {
-				mapped <- u
+				if !send(o.ctx, errs, err) {
+					return
+				}
+			} else if !send(o.ctx, mapped, u) {
+				return
 			}
 		}
-		close(mapped)
-		close(errs)
 	}()
 	return mapped, errs
 }
 
-func FlatMapWithErr[T, U any](channel chan T, f func(T) (chan U, error)) (chan U, chan error) {
-	channels, errs := MapWithErr(channel, f)
-	return Flatten(channels), errs
+func FlatMapWithErr[T, U any](channel <-chan T, f func(T) (<-chan U, error), opts ...Option) (<-chan U, <-chan error) {
+	channels, errs := MapWithErr(channel, f, opts...)
+	return Flatten(channels, opts...), errs
 }
 
-func FilterWithErr[T any](channel chan T, p func(T) (bool, error)) (chan T, chan error) {
-	filtered := make(chan T)
-	errs := make(chan error)
+func FilterWithErr[T any](channel <-chan T, p func(T) (bool, error), opts ...Option) (<-chan T, <-chan error) {
+	o := newOptions(opts)
+	filtered := makeChan[T](o)
+	errs := makeChan[error](o)
 	go func() {
-		for t := range channel {
+		defer close(errs)
+		defer close(filtered)
+		for t := range receiveAll(o.ctx, channel) {
 			ok, err := p(t)
 			if err != nil {
-				errs <- err
-			} else if ok {
-				filtered <- t
+				if !send(o.ctx, errs, err) {
+					return
+				}
+			} else if ok && !send(o.ctx, filtered, t) {
+				return
 			}
 		}
-		close(filtered)
-		close(errs)
 	}()
 	return filtered, errs
 }
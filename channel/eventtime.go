@@ -0,0 +1,37 @@
+package channel
+
+import (
+	"time"
+)
+
+// Timestamped is a value along with the time of the event it describes.
+type Timestamped[T any] struct {
+	Value T
+	Time  time.Time
+	// Watermark is set by Watermark to the watermark at the time Value was
+	// received. Values with an earlier Time are not expected anymore.
+	Watermark time.Time
+}
+
+// AssignTimestamps pairs every value of channel with its event time.
+func AssignTimestamps[T any](channel <-chan T, timestamp func(T) time.Time, opts ...Option) <-chan Timestamped[T] {
+	return Map(channel, func(t T) Timestamped[T] { return Timestamped[T]{Value: t, Time: timestamp(t)} }, opts...)
+}
+
+// Watermark tracks the progress of event time in channel, allowing values to
+// arrive up to lateness after a later value. The watermark is the latest event
+// time received so far minus lateness, and is recorded in every value. Values
+// that are on time are sent on the first returned channel, while values older
+// than the watermark are sent on the second one, the late data. The outputs
+// are buffered like those of Split, so the late data should be drained even
+// if it is not needed.
+func Watermark[T any](channel <-chan Timestamped[T], lateness time.Duration, opts ...Option) (<-chan Timestamped[T], <-chan Timestamped[T]) {
+	marked := MapStateful(channel, time.Time{}, func(latest time.Time, t Timestamped[T]) (time.Time, Timestamped[T]) {
+		if t.Time.After(latest) {
+			latest = t.Time
+		}
+		t.Watermark = latest.Add(-lateness)
+		return latest, t
+	}, opts...)
+	return Split(marked, func(t Timestamped[T]) bool { return !t.Time.Before(t.Watermark) }, opts...)
+}
@@ -0,0 +1,86 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+	"time"
+)
+
+func TestWatermark(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	minutes := func(m int) time.Time { return start.Add(time.Duration(m) * time.Minute) }
+	cases := []struct {
+		name string
+		// input holds the event time of every value, in minutes after start
+		input    []int
+		lateness time.Duration
+		wantOn   []int
+		wantLate []int
+	}{
+		{
+			name:     "empty",
+			input:    []int{},
+			lateness: time.Minute,
+			wantOn:   nil,
+			wantLate: nil,
+		},
+		{
+			name:     "in_order",
+			input:    []int{1, 2, 3},
+			lateness: 0,
+			wantOn:   []int{1, 2, 3},
+			wantLate: nil,
+		},
+		{
+			name:     "within_lateness",
+			input:    []int{1, 5, 3, 6},
+			lateness: 2 * time.Minute,
+			wantOn:   []int{1, 5, 3, 6},
+			wantLate: nil,
+		},
+		{
+			name:     "late",
+			input:    []int{1, 5, 2, 6, 4},
+			lateness: 2 * time.Minute,
+			wantOn:   []int{1, 5, 6, 4},
+			wantLate: []int{2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			timestamped := AssignTimestamps(FromSlice(tc.input), minutes)
+			onTime, late := Watermark(timestamped, tc.lateness)
+			value := func(t Timestamped[int]) int { return t.Value }
+			gotOn := ToSlice(Map(onTime, value))
+			gotLate := ToSlice(Map(late, value))
+			if diff := cmp.Diff(gotOn, tc.wantOn); diff != "" {
+				t.Errorf("unexpected on time result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(gotLate, tc.wantLate); diff != "" {
+				t.Errorf("unexpected late result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWatermarkRecordsWatermark(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	input := []Timestamped[string]{
+		{Value: "a", Time: start},
+		{Value: "b", Time: start.Add(10 * time.Minute)},
+		{Value: "c", Time: start.Add(9 * time.Minute)},
+	}
+	onTime, late := Watermark(FromSlice(input), 5*time.Minute)
+	go Drain(late)
+	got := ToSlice(Map(onTime, func(t Timestamped[string]) time.Time { return t.Watermark }))
+	want := []time.Time{start.Add(-5 * time.Minute), start.Add(5 * time.Minute), start.Add(5 * time.Minute)}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,179 @@
+package channel
+
+import (
+	"bufio"
+	"cmp"
+	"container/heap"
+	"context"
+	"encoding/gob"
+	"errors"
+	"golang.org/x/exp/constraints"
+	"io"
+	"os"
+	"slices"
+)
+
+const defaultRunSize = 1 << 16
+
+// SortedExternal is like Sorted but only holds a run of elements in memory at
+// a time, as set by WithRunSize. Sorted runs are encoded with encoding/gob into
+// temporary files in the directory set by WithTempDir and merged once channel
+// is exhausted. The first error encountered is sent on the returned error
+// channel, which is buffered so it can be checked after the sorted channel has
+// been drained. If the context given by WithContext is done before sorting
+// finishes, its error is sent on the error channel. After an error the sorted
+// channel is closed and the rest of channel is left unread, so pass the same
+// context to the stages producing channel and cancel it to release them.
+func SortedExternal[T constraints.Ordered](channel <-chan T, opts ...Option) (<-chan T, <-chan error) {
+	o := newOptions(opts)
+	ordered := make(chan T)
+	errs := make(chan error, 1)
+	runSize := o.runSize
+	if runSize <= 0 {
+		runSize = defaultRunSize
+	}
+	go func() {
+		defer close(errs)
+		defer close(ordered)
+		var runs []string
+		defer func() {
+			for _, run := range runs {
+				_ = os.Remove(run)
+			}
+		}()
+		buf := make([]T, 0, runSize)
+		for t := range receiveAll(o.ctx, channel) {
+			buf = append(buf, t)
+			if len(buf) == runSize {
+				run, err := spillRun(buf, o.tempDir)
+				if run != "" {
+					runs = append(runs, run)
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+				buf = buf[:0]
+			}
+		}
+		if err := o.ctx.Err(); err != nil {
+			errs <- err
+			return
+		}
+		slices.Sort(buf)
+		if len(runs) == 0 {
+			for _, t := range buf {
+				if !send(o.ctx, ordered, t) {
+					errs <- o.ctx.Err()
+					return
+				}
+			}
+			return
+		}
+		if err := mergeRuns(o.ctx, runs, buf, ordered); err != nil {
+			errs <- err
+		}
+	}()
+	return ordered, errs
+}
+
+func spillRun[T constraints.Ordered](buf []T, dir string) (string, error) {
+	slices.Sort(buf)
+	file, err := os.CreateTemp(dir, "functional-sort-*")
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriter(file)
+	encoder := gob.NewEncoder(w)
+	for _, t := range buf {
+		if err := encoder.Encode(t); err != nil {
+			return file.Name(), errors.Join(err, file.Close())
+		}
+	}
+	return file.Name(), errors.Join(w.Flush(), file.Close())
+}
+
+func mergeRuns[T constraints.Ordered](ctx context.Context, runs []string, remaining []T, ordered chan T) error {
+	sources := make(runHeap[T], 0, len(runs)+1)
+	for _, run := range runs {
+		file, err := os.Open(run)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		decoder := gob.NewDecoder(bufio.NewReader(file))
+		next := func() (T, bool, error) {
+			var t T
+			if err := decoder.Decode(&t); err != nil {
+				if errors.Is(err, io.EOF) {
+					return t, false, nil
+				}
+				return t, false, err
+			}
+			return t, true, nil
+		}
+		sources = append(sources, &runSource[T]{next: next})
+	}
+	sources = append(sources, &runSource[T]{next: func() (T, bool, error) {
+		var t T
+		if len(remaining) == 0 {
+			return t, false, nil
+		}
+		t, remaining = remaining[0], remaining[1:]
+		return t, true, nil
+	}})
+
+	live := sources[:0]
+	for _, source := range sources {
+		ok, err := source.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			live = append(live, source)
+		}
+	}
+	sources = live
+	heap.Init(&sources)
+	for sources.Len() > 0 {
+		source := sources[0]
+		if !send(ctx, ordered, source.head) {
+			return ctx.Err()
+		}
+		ok, err := source.advance()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(&sources, 0)
+		} else {
+			heap.Pop(&sources)
+		}
+	}
+	return nil
+}
+
+type runSource[T constraints.Ordered] struct {
+	head T
+	next func() (T, bool, error)
+}
+
+func (s *runSource[T]) advance() (bool, error) {
+	t, ok, err := s.next()
+	s.head = t
+	return ok, err
+}
+
+type runHeap[T constraints.Ordered] []*runSource[T]
+
+func (h runHeap[T]) Len() int           { return len(h) }
+func (h runHeap[T]) Less(i, j int) bool { return cmp.Less(h[i].head, h[j].head) }
+func (h runHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *runHeap[T]) Push(x any)        { *h = append(*h, x.(*runSource[T])) }
+func (h *runHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
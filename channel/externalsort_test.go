@@ -0,0 +1,78 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"io/fs"
+	"path/filepath"
+	"testing"
+)
+
+func TestSortedExternal(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		input   []int
+		runSize int
+		want    []int
+	}{
+		{
+			name:    "empty",
+			input:   []int{},
+			runSize: 3,
+			want:    nil,
+		},
+		{
+			name:    "in_memory",
+			input:   []int{3, 1, 2},
+			runSize: 10,
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "many_runs",
+			input:   []int{2, 1, 3, 5, 10, 9, 6, 8, 4, 7},
+			runSize: 3,
+			want:    []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			name:    "exact_runs",
+			input:   []int{6, 5, 4, 3, 2, 1},
+			runSize: 2,
+			want:    []int{1, 2, 3, 4, 5, 6},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			sorted, errs := SortedExternal(input, WithRunSize(tc.runSize), WithTempDir(t.TempDir()))
+			got := ToSlice(sorted)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if err := <-errs; err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSortedExternalSpillError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source, _ := naturals(WithContext(ctx))
+	dir := filepath.Join(t.TempDir(), "missing")
+	sorted, errs := SortedExternal(source, WithRunSize(4), WithTempDir(dir), WithContext(ctx))
+	if got := ToSlice(sorted); len(got) != 0 {
+		t.Errorf("unexpected result: got %v, want none", got)
+	}
+	if err := <-errs; !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got error %v, want %v", err, fs.ErrNotExist)
+	}
+}
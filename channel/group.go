@@ -0,0 +1,37 @@
+package channel
+
+import "github.com/lock14/functional/tuple"
+
+// GroupBy routes each value of channel to a sub-channel determined by keyFn.
+// The first time a key is seen, a new sub-channel with the given buffer size
+// is emitted together with its key. All sub-channels are closed once channel
+// is exhausted. Since values are routed in order, every emitted sub-channel
+// must be consumed concurrently or the grouping will block.
+func GroupBy[T any, K comparable](channel <-chan T, keyFn func(T) K, bufferSize int, opts ...Option) <-chan tuple.Pair[K, <-chan T] {
+	o := newOptions(opts)
+	groups := makeChan[tuple.Pair[K, <-chan T]](o)
+	go func() {
+		subChannels := make(map[K]chan T)
+		defer func() {
+			for _, subChannel := range subChannels {
+				close(subChannel)
+			}
+			close(groups)
+		}()
+		for t := range receiveAll(o.ctx, channel) {
+			key := keyFn(t)
+			subChannel, ok := subChannels[key]
+			if !ok {
+				subChannel = make(chan T, bufferSize)
+				subChannels[key] = subChannel
+				if !send(o.ctx, groups, tuple.Pair[K, <-chan T]{Fst: key, Snd: subChannel}) {
+					return
+				}
+			}
+			if !send(o.ctx, subChannel, t) {
+				return
+			}
+		}
+	}()
+	return groups
+}
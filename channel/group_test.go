@@ -0,0 +1,71 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		input      []int
+		bufferSize int
+		want       map[int][]int
+	}{
+		{
+			name:       "empty",
+			input:      []int{},
+			bufferSize: 0,
+			want:       map[int][]int{},
+		},
+		{
+			name:       "unbuffered",
+			input:      []int{1, 2, 3, 4, 5, 6, 7},
+			bufferSize: 0,
+			want: map[int][]int{
+				0: {3, 6},
+				1: {1, 4, 7},
+				2: {2, 5},
+			},
+		},
+		{
+			name:       "buffered",
+			input:      []int{1, 2, 3, 4, 5, 6, 7},
+			bufferSize: 10,
+			want: map[int][]int{
+				0: {3, 6},
+				1: {1, 4, 7},
+				2: {2, 5},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			groups := GroupBy(input, func(i int) int { return i % 3 }, tc.bufferSize)
+			got := make(map[int][]int)
+			mu := sync.Mutex{}
+			waitGroup := sync.WaitGroup{}
+			for group := range groups {
+				waitGroup.Add(1)
+				go func() {
+					defer waitGroup.Done()
+					values := ToSlice(group.Snd)
+					mu.Lock()
+					defer mu.Unlock()
+					got[group.Fst] = values
+				}()
+			}
+			waitGroup.Wait()
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package channel
+
+import (
+	"context"
+	"sync"
+)
+
+// Hub is an in-process publish/subscribe event bus. Values published to the
+// hub are routed to every subscriber of the value's topic, as determined by
+// the hub's topic function. Delivery to a subscriber blocks the publisher
+// until the subscriber accepts the value, unless the subscriber's channel is
+// buffered, see WithBuffer.
+type Hub[T any, K comparable] struct {
+	topic       func(T) K
+	mu          sync.RWMutex
+	subscribers map[*subscriber[T, K]]struct{}
+	closed      bool
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+type subscriber[T any, K comparable] struct {
+	topics map[K]struct{}
+	c      chan T
+	done   chan struct{}
+}
+
+func NewHub[T any, K comparable](topic func(T) K) *Hub[T, K] {
+	return &Hub[T, K]{
+		topic:       topic,
+		subscribers: make(map[*subscriber[T, K]]struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Publish publishes every value of channel to the hub, returning once channel
+// is closed or the context given by WithContext is done. Values published
+// after the hub is closed are discarded.
+func (h *Hub[T, K]) Publish(channel <-chan T, opts ...Option) {
+	o := newOptions(opts)
+	for t := range receiveAll(o.ctx, channel) {
+		h.publish(o.ctx, t)
+	}
+}
+
+func (h *Hub[T, K]) publish(ctx context.Context, t T) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return
+	}
+	key := h.topic(t)
+	for s := range h.subscribers {
+		if _, ok := s.topics[key]; len(s.topics) > 0 && !ok {
+			continue
+		}
+		select {
+		case s.c <- t:
+		case <-s.done:
+		case <-h.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the values published to any of
+// topics, or to every topic if none are given, along with a function that
+// cancels the subscription and closes the channel.
+func (h *Hub[T, K]) Subscribe(topics []K, opts ...Option) (<-chan T, func()) {
+	s := &subscriber[T, K]{
+		topics: make(map[K]struct{}, len(topics)),
+		c:      makeChan[T](newOptions(opts)),
+		done:   make(chan struct{}),
+	}
+	for _, topic := range topics {
+		s.topics[topic] = struct{}{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(s.c)
+		return s.c, func() {}
+	}
+	h.subscribers[s] = struct{}{}
+	once := sync.Once{}
+	unsubscribe := func() {
+		once.Do(func() {
+			// unblock any publisher delivering to s before waiting for the lock
+			close(s.done)
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if _, ok := h.subscribers[s]; ok {
+				delete(h.subscribers, s)
+				close(s.c)
+			}
+		})
+	}
+	return s.c, unsubscribe
+}
+
+// Close closes the channels of all subscribers. Deliveries that are blocked
+// on a subscriber that is not receiving are abandoned.
+func (h *Hub[T, K]) Close() {
+	// unblock any publisher delivering to a subscriber before waiting for the
+	// lock
+	h.closeOnce.Do(func() { close(h.done) })
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for s := range h.subscribers {
+		close(s.c)
+	}
+	clear(h.subscribers)
+}
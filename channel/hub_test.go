@@ -0,0 +1,73 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(func(i int) string {
+		if i%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	evens, _ := hub.Subscribe([]string{"even"}, WithBuffer(10))
+	all, _ := hub.Subscribe(nil, WithBuffer(10))
+	unsubscribed, unsubscribe := hub.Subscribe([]string{"odd"})
+	unsubscribe()
+
+	var gotEvens, gotAll []int
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(2)
+	go func() {
+		defer waitGroup.Done()
+		gotEvens = ToSlice(evens)
+	}()
+	go func() {
+		defer waitGroup.Done()
+		gotAll = ToSlice(all)
+	}()
+
+	hub.Publish(Range(1, 8))
+	hub.Close()
+	waitGroup.Wait()
+
+	if diff := cmp.Diff(gotEvens, []int{2, 4, 6}); diff != "" {
+		t.Errorf("unexpected result for evens (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(gotAll, []int{1, 2, 3, 4, 5, 6, 7}); diff != "" {
+		t.Errorf("unexpected result for all (-got, +want): %s", diff)
+	}
+	if _, ok := <-unsubscribed; ok {
+		t.Error("expected unsubscribed to be closed")
+	}
+	// subscribing to a closed hub returns a closed channel
+	closed, _ := hub.Subscribe(nil)
+	if _, ok := <-closed; ok {
+		t.Error("expected subscription to a closed hub to be closed")
+	}
+}
+
+func TestHubCloseWithBlockedPublisher(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(func(i int) int { return i })
+	slow, _ := hub.Subscribe(nil)
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		hub.Publish(FromSlice([]int{1}))
+	}()
+	// let the publisher block on the subscriber, which never receives
+	time.Sleep(10 * time.Millisecond)
+	hub.Close()
+	<-published
+	if _, ok := <-slow; ok {
+		t.Error("expected slow to be closed")
+	}
+}
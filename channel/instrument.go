@@ -0,0 +1,55 @@
+package channel
+
+import (
+	"time"
+)
+
+// ElementStats describes a single element passing through an instrumented
+// stage.
+type ElementStats struct {
+	// Count is the number of elements seen by the stage so far, including
+	// this one.
+	Count int64
+	// InterArrival is the time spent waiting on the upstream for this element.
+	// A consistently high value means the stage is starved by its producer.
+	InterArrival time.Duration
+	// Blocked is the time spent waiting for the downstream to accept this
+	// element. A consistently high value means the consumer is the bottleneck.
+	Blocked time.Duration
+}
+
+// Observer receives the measurements of an instrumented stage. Calls for a
+// single stage are made sequentially from the goroutine running that stage,
+// and OnClose is called before the instrumented channel is closed.
+type Observer interface {
+	OnElement(stage string, stats ElementStats)
+	OnClose(stage string, count int64)
+}
+
+// Instrument forwards every value of channel unchanged while reporting
+// measurements for the named stage to observer.
+func Instrument[T any](channel <-chan T, name string, observer Observer, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	instrumented := makeChan[T](o)
+	go func() {
+		var count int64
+		waitStart := o.clock.Now()
+		for t := range receiveAll(o.ctx, channel) {
+			arrived := o.clock.Now()
+			if !send(o.ctx, instrumented, t) {
+				break
+			}
+			count++
+			sent := o.clock.Now()
+			observer.OnElement(name, ElementStats{
+				Count:        count,
+				InterArrival: arrived.Sub(waitStart),
+				Blocked:      sent.Sub(arrived),
+			})
+			waitStart = o.clock.Now()
+		}
+		observer.OnClose(name, count)
+		close(instrumented)
+	}()
+	return instrumented
+}
@@ -0,0 +1,45 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"testing"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	counts map[string][]int64
+	closed map[string]int64
+}
+
+func (o *recordingObserver) OnElement(stage string, stats ElementStats) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.counts[stage] = append(o.counts[stage], stats.Count)
+}
+
+func (o *recordingObserver) OnClose(stage string, count int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed[stage] = count
+}
+
+func TestInstrument(t *testing.T) {
+	t.Parallel()
+
+	observer := &recordingObserver{counts: make(map[string][]int64), closed: make(map[string]int64)}
+	input := Instrument(FromSlice([]int{1, 2, 3, 4}), "source", observer)
+	filtered := Instrument(Filter(input, func(i int) bool { return i%2 == 0 }), "filter", observer)
+	got := ToSlice(filtered)
+	if diff := cmp.Diff(got, []int{2, 4}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	wantCounts := map[string][]int64{"source": {1, 2, 3, 4}, "filter": {1, 2}}
+	if diff := cmp.Diff(observer.counts, wantCounts); diff != "" {
+		t.Errorf("unexpected counts (-got, +want): %s", diff)
+	}
+	wantClosed := map[string]int64{"source": 4, "filter": 2}
+	if diff := cmp.Diff(observer.closed, wantClosed); diff != "" {
+		t.Errorf("unexpected closed counts (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,185 @@
+package channel
+
+import (
+	"container/list"
+	"github.com/lock14/functional/tuple"
+)
+
+// JoinType determines which unmatched values JoinByKey emits.
+type JoinType int
+
+const (
+	// InnerJoin only emits values that have a match on the other side.
+	InnerJoin JoinType = iota
+	// LeftJoin additionally emits every unmatched left value paired with the
+	// zero value of the right side.
+	LeftJoin
+	// OuterJoin additionally emits every unmatched value of either side paired
+	// with the zero value of the other side.
+	OuterJoin
+)
+
+// JoinByKey joins left and right by the keys extracted with leftKey and
+// rightKey. Both channels are consumed concurrently and every pair of values
+// with equal keys is emitted as soon as its second value arrives. Unmatched
+// values are emitted according to joinType once both channels are closed; use
+// pointer types to tell them apart from zero values. Every value is retained
+// until both channels are closed.
+func JoinByKey[L, R any, K comparable](left <-chan L, right <-chan R, leftKey func(L) K, rightKey func(R) K, joinType JoinType, opts ...Option) <-chan tuple.Pair[L, R] {
+	o := newOptions(opts)
+	joined := makeChan[tuple.Pair[L, R]](o)
+	go func() {
+		defer close(joined)
+		lefts := make(map[K][]*joinEntry[L])
+		rights := make(map[K][]*joinEntry[R])
+		for left != nil || right != nil {
+			select {
+			case l, ok := <-left:
+				if !ok {
+					left = nil
+					continue
+				}
+				entry := &joinEntry[L]{value: l}
+				k := leftKey(l)
+				lefts[k] = append(lefts[k], entry)
+				for _, r := range rights[k] {
+					entry.matched, r.matched = true, true
+					if !send(o.ctx, joined, tuple.Pair[L, R]{Fst: l, Snd: r.value}) {
+						return
+					}
+				}
+			case r, ok := <-right:
+				if !ok {
+					right = nil
+					continue
+				}
+				entry := &joinEntry[R]{value: r}
+				k := rightKey(r)
+				rights[k] = append(rights[k], entry)
+				for _, l := range lefts[k] {
+					entry.matched, l.matched = true, true
+					if !send(o.ctx, joined, tuple.Pair[L, R]{Fst: l.value, Snd: r}) {
+						return
+					}
+				}
+			case <-o.ctx.Done():
+				return
+			}
+		}
+		if joinType == LeftJoin || joinType == OuterJoin {
+			for _, entries := range lefts {
+				for _, l := range entries {
+					if !l.matched && !send(o.ctx, joined, tuple.Pair[L, R]{Fst: l.value}) {
+						return
+					}
+				}
+			}
+		}
+		if joinType == OuterJoin {
+			for _, entries := range rights {
+				for _, r := range entries {
+					if !r.matched && !send(o.ctx, joined, tuple.Pair[L, R]{Snd: r.value}) {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return joined
+}
+
+type joinEntry[T any] struct {
+	value   T
+	matched bool
+}
+
+// ZipByKey pairs every value of chan1 with the value of chan2 that has the same
+// key, as extracted with key1 and key2, regardless of the positions in which
+// they arrive. Unlike JoinByKey, each value is paired at most once, with the
+// earliest unpaired value of the other side. At most maxPending unpaired values
+// are buffered per side; beyond that the oldest one is dropped, so that a gap
+// in one channel does not hold on to the other indefinitely. Values that are
+// still unpaired once both channels are closed are dropped.
+func ZipByKey[T, U any, K comparable](chan1 <-chan T, chan2 <-chan U, key1 func(T) K, key2 func(U) K, maxPending int, opts ...Option) <-chan tuple.Pair[T, U] {
+	o := newOptions(opts)
+	zipped := makeChan[tuple.Pair[T, U]](o)
+	go func() {
+		defer close(zipped)
+		pending1 := newPendingByKey[K, T](maxPending)
+		pending2 := newPendingByKey[K, U](maxPending)
+		for chan1 != nil || chan2 != nil {
+			select {
+			case t, ok := <-chan1:
+				if !ok {
+					chan1 = nil
+					continue
+				}
+				k := key1(t)
+				if u, ok := pending2.take(k); ok {
+					if !send(o.ctx, zipped, tuple.Pair[T, U]{Fst: t, Snd: u}) {
+						return
+					}
+				} else {
+					pending1.add(k, t)
+				}
+			case u, ok := <-chan2:
+				if !ok {
+					chan2 = nil
+					continue
+				}
+				k := key2(u)
+				if t, ok := pending1.take(k); ok {
+					if !send(o.ctx, zipped, tuple.Pair[T, U]{Fst: t, Snd: u}) {
+						return
+					}
+				} else {
+					pending2.add(k, u)
+				}
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return zipped
+}
+
+// pendingByKey buffers values by key in arrival order, evicting the oldest
+// value once more than max are buffered.
+type pendingByKey[K comparable, T any] struct {
+	max   int
+	order *list.List
+	byKey map[K][]*list.Element
+}
+
+type pendingEntry[K comparable, T any] struct {
+	key   K
+	value T
+}
+
+func newPendingByKey[K comparable, T any](max int) *pendingByKey[K, T] {
+	return &pendingByKey[K, T]{max: max, order: list.New(), byKey: make(map[K][]*list.Element)}
+}
+
+func (p *pendingByKey[K, T]) add(k K, t T) {
+	p.byKey[k] = append(p.byKey[k], p.order.PushBack(pendingEntry[K, T]{key: k, value: t}))
+	if p.order.Len() > p.max {
+		oldest := p.order.Front().Value.(pendingEntry[K, T])
+		// the oldest value is also the first one buffered for its key
+		p.take(oldest.key)
+	}
+}
+
+// take removes and returns the oldest value buffered for k.
+func (p *pendingByKey[K, T]) take(k K) (T, bool) {
+	elements := p.byKey[k]
+	if len(elements) == 0 {
+		var zero T
+		return zero, false
+	}
+	if len(elements) == 1 {
+		delete(p.byKey, k)
+	} else {
+		p.byKey[k] = elements[1:]
+	}
+	return p.order.Remove(elements[0]).(pendingEntry[K, T]).value, true
+}
@@ -0,0 +1,152 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lock14/functional/tuple"
+	"strconv"
+	"testing"
+)
+
+func TestJoinByKey(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	type order struct {
+		UserID int
+		Item   string
+	}
+	users := []user{{1, "bob"}, {2, "mary"}, {3, "jane"}}
+	orders := []order{{1, "book"}, {1, "pen"}, {3, "lamp"}, {4, "desk"}}
+	cases := []struct {
+		name     string
+		joinType JoinType
+		want     []tuple.Pair[user, order]
+	}{
+		{
+			name:     "inner",
+			joinType: InnerJoin,
+			want: []tuple.Pair[user, order]{
+				{Fst: users[0], Snd: orders[0]},
+				{Fst: users[0], Snd: orders[1]},
+				{Fst: users[2], Snd: orders[2]},
+			},
+		},
+		{
+			name:     "left",
+			joinType: LeftJoin,
+			want: []tuple.Pair[user, order]{
+				{Fst: users[0], Snd: orders[0]},
+				{Fst: users[0], Snd: orders[1]},
+				{Fst: users[2], Snd: orders[2]},
+				{Fst: users[1]},
+			},
+		},
+		{
+			name:     "outer",
+			joinType: OuterJoin,
+			want: []tuple.Pair[user, order]{
+				{Fst: users[0], Snd: orders[0]},
+				{Fst: users[0], Snd: orders[1]},
+				{Fst: users[2], Snd: orders[2]},
+				{Fst: users[1]},
+				{Snd: orders[3]},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			joined := JoinByKey(FromSlice(users), FromSlice(orders),
+				func(u user) int { return u.ID },
+				func(o order) int { return o.UserID },
+				tc.joinType)
+			got := ToSlice(joined)
+			sortPairs := cmpopts.SortSlices(func(a, b tuple.Pair[user, order]) bool {
+				if a.Fst.ID != b.Fst.ID {
+					return a.Fst.ID < b.Fst.ID
+				}
+				return a.Snd.Item < b.Snd.Item
+			})
+			if diff := cmp.Diff(got, tc.want, sortPairs); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestZipByKey(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		input1     []int
+		input2     []string
+		maxPending int
+		want       []tuple.Pair[int, string]
+	}{
+		{
+			name:       "empty",
+			input1:     []int{},
+			input2:     []string{},
+			maxPending: 2,
+			want:       nil,
+		},
+		{
+			name:       "out_of_order",
+			input1:     []int{1, 2, 3},
+			input2:     []string{"3", "1", "2"},
+			maxPending: 3,
+			want:       []tuple.Pair[int, string]{{Fst: 3, Snd: "3"}, {Fst: 1, Snd: "1"}, {Fst: 2, Snd: "2"}},
+		},
+		{
+			name:       "gaps",
+			input1:     []int{1, 2, 4},
+			input2:     []string{"1", "3", "4"},
+			maxPending: 3,
+			want:       []tuple.Pair[int, string]{{Fst: 1, Snd: "1"}, {Fst: 4, Snd: "4"}},
+		},
+		{
+			name:       "repeated_keys_pair_once",
+			input1:     []int{1, 1},
+			input2:     []string{"1", "1", "1"},
+			maxPending: 3,
+			want:       []tuple.Pair[int, string]{{Fst: 1, Snd: "1"}, {Fst: 1, Snd: "1"}},
+		},
+		{
+			name:       "oldest_pending_dropped",
+			input1:     []int{1, 2, 3},
+			input2:     []string{"1", "2", "3"},
+			maxPending: 2,
+			want:       []tuple.Pair[int, string]{{Fst: 2, Snd: "2"}, {Fst: 3, Snd: "3"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			chan1, chan2 := make(chan int), make(chan string)
+			// chan1 is fully received before anything is sent on chan2, which
+			// makes the buffering deterministic
+			go func() {
+				for _, i := range tc.input1 {
+					chan1 <- i
+				}
+				close(chan1)
+				for _, s := range tc.input2 {
+					chan2 <- s
+				}
+				close(chan2)
+			}()
+			got := ToSlice(ZipByKey(chan1, chan2, strconv.Itoa, func(s string) string { return s }, tc.maxPending))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package channel
+
+import (
+	"github.com/lock14/functional/tuple"
+	"iter"
+)
+
+// MapValues applies f to the value of every key/value pair of channel, keeping
+// its key.
+func MapValues[K, V, W any](channel <-chan tuple.Pair[K, V], f func(V) W, opts ...Option) <-chan tuple.Pair[K, W] {
+	return Map(channel, func(p tuple.Pair[K, V]) tuple.Pair[K, W] { return tuple.MapSecond(p, f) }, opts...)
+}
+
+// FilterKeys keeps the key/value pairs of channel whose key satisfies p.
+func FilterKeys[K, V any](channel <-chan tuple.Pair[K, V], p func(K) bool, opts ...Option) <-chan tuple.Pair[K, V] {
+	return Filter(channel, func(pair tuple.Pair[K, V]) bool { return p(pair.Fst) }, opts...)
+}
+
+// ReduceByKey combines the values of channel that share the key extracted with
+// keyFn using reduce, in the order they are received.
+func ReduceByKey[T any, K comparable](channel <-chan T, keyFn func(T) K, reduce func(T, T) T) map[K]T {
+	m := make(map[K]T)
+	for t := range channel {
+		k := keyFn(t)
+		if existing, ok := m[k]; ok {
+			m[k] = reduce(existing, t)
+		} else {
+			m[k] = t
+		}
+	}
+	return m
+}
+
+// ToSeq2 returns the key/value pairs of channel as an iter.Seq2, so they can
+// be ranged over without unpacking each pair. The returned sequence receives
+// from channel and so can only be iterated once.
+func ToSeq2[K, V any](channel <-chan tuple.Pair[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range channel {
+			if !yield(p.Fst, p.Snd) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/tuple"
+	"strings"
+	"testing"
+)
+
+func TestMapValues(t *testing.T) {
+	t.Parallel()
+
+	input := []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "b", Snd: 2}}
+	want := []tuple.Pair[string, int]{{Fst: "a", Snd: 10}, {Fst: "b", Snd: 20}}
+	got := ToSlice(MapValues(FromSlice(input), func(i int) int { return i * 10 }))
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestFilterKeys(t *testing.T) {
+	t.Parallel()
+
+	input := []tuple.Pair[string, int]{{Fst: "apple", Snd: 1}, {Fst: "banana", Snd: 2}, {Fst: "avocado", Snd: 3}}
+	want := []tuple.Pair[string, int]{{Fst: "apple", Snd: 1}, {Fst: "avocado", Snd: 3}}
+	got := ToSlice(FilterKeys(FromSlice(input), func(k string) bool { return strings.HasPrefix(k, "a") }))
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestReduceByKey(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  map[byte]string
+	}{
+		{
+			name:  "empty",
+			input: []string{},
+			want:  map[byte]string{},
+		},
+		{
+			name:  "many",
+			input: []string{"apple", "banana", "avocado", "blueberry", "cherry"},
+			want:  map[byte]string{'a': "apple+avocado", 'b': "banana+blueberry", 'c': "cherry"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ReduceByKey(FromSlice(tc.input), func(s string) byte { return s[0] }, func(a, b string) string { return a + "+" + b })
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToSeq2(t *testing.T) {
+	t.Parallel()
+
+	input := []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "b", Snd: 2}, {Fst: "c", Snd: 3}}
+	var keys []string
+	var values []int
+	for k, v := range ToSeq2(FromSlice(input)) {
+		if k == "c" {
+			break
+		}
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if diff := cmp.Diff(keys, []string{"a", "b"}); diff != "" {
+		t.Errorf("unexpected keys (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(values, []int{1, 2}); diff != "" {
+		t.Errorf("unexpected values (-got, +want): %s", diff)
+	}
+}
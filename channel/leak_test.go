@@ -0,0 +1,257 @@
+package channel
+
+import (
+	"context"
+	"github.com/lock14/functional/functest"
+	"golang.org/x/sync/errgroup"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// naturals returns the never ending sequence 0, 1, 2, ... along with the
+// number of values it has produced so far.
+func naturals(opts ...Option) (<-chan int, func() int64) {
+	var produced atomic.Int64
+	ch := Iterate(0, func(int) bool { return true }, func(i int) int {
+		produced.Add(1)
+		return i + 1
+	}, opts...)
+	return ch, produced.Load
+}
+
+func TestShortCircuitingOperatorsDoNotLeak(t *testing.T) {
+	cases := []struct {
+		name string
+		// run reads the operator under test to its end, given two never
+		// ending sources
+		run func(source1, source2 <-chan int, opt Option)
+	}{
+		{
+			name: "limit",
+			run: func(source1, _ <-chan int, opt Option) {
+				ToSlice(Limit(source1, 3, opt))
+			},
+		},
+		{
+			name: "take_while",
+			run: func(source1, _ <-chan int, opt Option) {
+				ToSlice(TakeWhile(source1, func(i int) bool { return i < 3 }, opt))
+			},
+		},
+		{
+			name: "zip_first_ends",
+			run: func(_, source2 <-chan int, opt Option) {
+				ToSlice(Zip(Range(0, 3, opt), source2, opt))
+			},
+		},
+		{
+			name: "zip_second_ends",
+			run: func(source1, _ <-chan int, opt Option) {
+				ToSlice(Zip(source1, Range(0, 3, opt), opt))
+			},
+		},
+		{
+			name: "count_at_most",
+			run: func(source1, _ <-chan int, opt Option) {
+				CountAtMost(source1, 3, opt)
+			},
+		},
+		{
+			name: "any_match",
+			run: func(source1, _ <-chan int, opt Option) {
+				AnyMatch(source1, func(i int) bool { return i == 3 }, opt)
+			},
+		},
+		{
+			name: "all_match",
+			run: func(source1, _ <-chan int, opt Option) {
+				AllMatch(source1, func(i int) bool { return i < 3 }, opt)
+			},
+		},
+		{
+			name: "for_each_while",
+			run: func(source1, _ <-chan int, opt Option) {
+				ForEachWhile(source1, func(i int) bool { return i < 3 }, opt)
+			},
+		},
+		{
+			name: "race",
+			run: func(source1, source2 <-chan int, opt Option) {
+				Race([]<-chan int{source1, source2}, opt)
+			},
+		},
+		{
+			name: "encode_to_fails",
+			run: func(source1, _ <-chan int, _ Option) {
+				_ = EncodeTo(failingWriter{}, source1, JSONLines)
+			},
+		},
+		{
+			name: "write_with_fails",
+			run: func(source1, _ <-chan int, opt Option) {
+				_ = WriteWith(failingWriter{}, source1, func(int) []byte { return make([]byte, 8192) }, opt)
+			},
+		},
+		{
+			name: "go_each_fails",
+			run: func(source1, _ <-chan int, opt Option) {
+				var g errgroup.Group
+				g.SetLimit(1)
+				GoEach(&g, source1, func(i int) error {
+					if i == 3 {
+						return errWrite
+					}
+					return nil
+				}, opt)
+				_ = g.Wait()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			functest.RequireNoGoroutineLeak(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			source1, produced1 := naturals(WithContext(ctx))
+			source2, produced2 := naturals(WithContext(ctx))
+			tc.run(source1, source2, WithContext(ctx))
+			// the abandoned sources are left blocked on their pending value
+			// rather than drained
+			time.Sleep(10 * time.Millisecond)
+			before := produced1() + produced2()
+			time.Sleep(10 * time.Millisecond)
+			if after := produced1() + produced2(); after != before {
+				t.Errorf("sources produced %d values after the operator stopped", after-before)
+			}
+			cancel()
+		})
+	}
+}
+
+// receiveOne waits for a single value of channel, so that the stage producing
+// it is known to be running.
+func receiveOne[T any](channel <-chan T) {
+	<-channel
+}
+
+func TestWithContextDoesNotLeak(t *testing.T) {
+	identity := func(i int) int { return i }
+	cases := []struct {
+		name string
+		// run starts the operator on the never ending source, reading from it
+		// where the operator emits without seeing all of source
+		run func(source <-chan int, opt Option)
+	}{
+		{name: "map", run: func(s <-chan int, opt Option) { receiveOne(Map(s, identity, opt)) }},
+		{name: "flat_map", run: func(s <-chan int, opt Option) {
+			receiveOne(FlatMap(s, func(i int) <-chan int { return Range(0, i+1, opt) }, opt))
+		}},
+		{name: "filter", run: func(s <-chan int, opt Option) { receiveOne(Filter(s, func(int) bool { return true }, opt)) }},
+		{name: "filter_map", run: func(s <-chan int, opt Option) {
+			receiveOne(FilterMap(s, func(i int) (int, bool) { return i, true }, opt))
+		}},
+		{name: "scan", run: func(s <-chan int, opt Option) { receiveOne(Scan(s, 0, func(a, b int) int { return a + b }, opt)) }},
+		{name: "zip", run: func(s <-chan int, opt Option) { receiveOne(Zip(s, Map(s, identity, opt), opt)) }},
+		{name: "unzip", run: func(s <-chan int, opt Option) {
+			first, _ := UnZip(Zip(s, Map(s, identity, opt), opt), opt)
+			receiveOne(first)
+		}},
+		{name: "sorted", run: func(s <-chan int, opt Option) { Sorted(s, opt) }},
+		{name: "distinct", run: func(s <-chan int, opt Option) { receiveOne(Distinct(s, opt)) }},
+		{name: "buffered", run: func(s <-chan int, opt Option) { receiveOne(Buffered(s, 3, opt)) }},
+		{name: "limit", run: func(s <-chan int, opt Option) { receiveOne(Limit(s, 10, opt)) }},
+		{name: "skip", run: func(s <-chan int, opt Option) { receiveOne(Skip(s, 1, opt)) }},
+		{name: "take_while", run: func(s <-chan int, opt Option) { receiveOne(TakeWhile(s, func(int) bool { return true }, opt)) }},
+		{name: "concat", run: func(s <-chan int, opt Option) { receiveOne(Concat(s, Range(0, 3, opt), opt)) }},
+		{name: "peek", run: func(s <-chan int, opt Option) { receiveOne(Peek(s, func(int) {}, opt)) }},
+		{name: "partition", run: func(s <-chan int, opt Option) { Partition(s, 2, opt) }},
+		{name: "chunk", run: func(s <-chan int, opt Option) { receiveOne(Chunk(s, 2, opt)) }},
+		{name: "flatten_slices", run: func(s <-chan int, opt Option) { receiveOne(FlattenSlices(Chunk(s, 2, opt), opt)) }},
+		{name: "clone", run: func(s <-chan int, opt Option) { receiveOne(Clone(s, 2, opt)[0]) }},
+		{name: "named", run: func(s <-chan int, opt Option) { receiveOne(Named(s, "stage", opt)) }},
+		{name: "buffer_with_watermarks", run: func(s <-chan int, opt Option) {
+			receiveOne(BufferWithWatermarks(s, 4, 3, 1, func(int) {}, func(int) {}, opt))
+		}},
+		{name: "distinct_limited", run: func(s <-chan int, opt Option) { receiveOne(DistinctLimited(s, 2, opt)) }},
+		{name: "drop_newest", run: func(s <-chan int, opt Option) { receiveOne(DropNewest(s, 2, opt)) }},
+		{name: "drop_oldest", run: func(s <-chan int, opt Option) { receiveOne(DropOldest(s, 2, opt)) }},
+		{name: "map_with_err", run: func(s <-chan int, opt Option) {
+			mapped, _ := MapWithErr(s, func(i int) (int, error) { return i, nil }, opt)
+			receiveOne(mapped)
+		}},
+		{name: "filter_with_err", run: func(s <-chan int, opt Option) {
+			filtered, _ := FilterWithErr(s, func(int) (bool, error) { return true, nil }, opt)
+			receiveOne(filtered)
+		}},
+		{name: "sorted_external", run: func(s <-chan int, opt Option) {
+			SortedExternal(s, WithRunSize(4), WithTempDir(t.TempDir()), opt)
+		}},
+		{name: "split", run: func(s <-chan int, opt Option) {
+			matched, _ := Split(s, func(i int) bool { return i%2 == 0 }, opt)
+			receiveOne(matched)
+		}},
+		{name: "group_by", run: func(s <-chan int, opt Option) {
+			receiveOne(GroupBy(s, func(i int) int { return i % 2 }, 1, opt))
+		}},
+		{name: "instrument", run: func(s <-chan int, opt Option) {
+			observer := &recordingObserver{counts: make(map[string][]int64), closed: make(map[string]int64)}
+			receiveOne(Instrument(s, "stage", observer, opt))
+		}},
+		{name: "join_by_key", run: func(s <-chan int, opt Option) {
+			receiveOne(JoinByKey(s, Range(0, 3, opt), identity, identity, InnerJoin, opt))
+		}},
+		{name: "zip_by_key", run: func(s <-chan int, opt Option) {
+			sameKey := func(int) int { return 0 }
+			receiveOne(ZipByKey(s, Range(0, 3, opt), sameKey, sameKey, 8, opt))
+		}},
+		{name: "parallel_map", run: func(s <-chan int, opt Option) { receiveOne(ParallelMap(s, identity, opt)) }},
+		{name: "parallel_flat_map", run: func(s <-chan int, opt Option) {
+			receiveOne(ParallelFlatMap(s, func(i int) <-chan int { return Range(0, i+1, opt) }, opt))
+		}},
+		{name: "parallel_filter", run: func(s <-chan int, opt Option) {
+			receiveOne(ParallelFilter(s, func(int) bool { return true }, opt))
+		}},
+		{name: "parallel_map_with_err", run: func(s <-chan int, opt Option) {
+			mapped, _ := ParallelMapWithErr(s, func(i int) (int, error) { return i, nil }, opt)
+			receiveOne(mapped)
+		}},
+		{name: "reorder", run: func(s <-chan int, opt Option) {
+			receiveOne(Reorder(s, 2, func(a, b int) bool { return a < b }, opt))
+		}},
+		{name: "priority_buffer", run: func(s <-chan int, opt Option) {
+			receiveOne(PriorityBuffer(s, func(a, b int) bool { return a < b }, 2, opt))
+		}},
+		{name: "replay", run: func(s <-chan int, opt Option) { receiveOne(NewReplay(s, 2, opt).Subscribe(opt)) }},
+		{name: "delay", run: func(s <-chan int, opt Option) { receiveOne(Delay(s, time.Millisecond, opt)) }},
+		{name: "spread", run: func(s <-chan int, opt Option) { receiveOne(Spread(s, time.Millisecond, opt)) }},
+		{name: "with_heartbeat", run: func(s <-chan int, opt Option) {
+			out, _ := WithHeartbeat(s, time.Millisecond, opt)
+			receiveOne(out)
+		}},
+		{name: "window", run: func(s <-chan int, opt Option) { receiveOne(Window(s, 2, 1, opt)) }},
+		{name: "window_by_time", run: func(s <-chan int, opt Option) { receiveOne(WindowByTime(s, time.Millisecond, time.Millisecond, opt)) }},
+		{name: "session_window", run: func(s <-chan int, opt Option) {
+			SessionWindow(s, func(int) time.Time { return time.Now() }, time.Millisecond, opt)
+		}},
+		{name: "rolling_average", run: func(s <-chan int, opt Option) { receiveOne(RollingAverage(s, 2, opt)) }},
+		{name: "rate", run: func(s <-chan int, opt Option) { receiveOne(Rate(s, time.Millisecond, opt)) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			functest.RequireNoGoroutineLeak(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			tc.run(GenerateCtx(ctx, (&StatefulSupplier{}).Supply), WithContext(ctx))
+		})
+	}
+}
+
+func TestMergePriorityWithContextDoesNotLeak(t *testing.T) {
+	functest.RequireNoGoroutineLeak(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first, _ := naturals(WithContext(ctx))
+	second, _ := naturals(WithContext(ctx))
+	receiveOne(MergePriority([]<-chan int{first, second}, WithContext(ctx)))
+}
@@ -0,0 +1,96 @@
+package channel
+
+import (
+	"reflect"
+)
+
+// MergePriority merges channels into a single channel, ordered from highest to
+// lowest priority. Whenever values are available on several channels, the
+// value from the channel with the highest priority is emitted first. The
+// merged channel is closed once all channels are closed.
+func MergePriority[T any](channels []<-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	merged := makeChan[T](o)
+	go func() {
+		defer close(merged)
+		open := make([]<-chan T, len(channels))
+		copy(open, channels)
+		remaining := len(open)
+		// the last case waits for the context to be done
+		cases := make([]reflect.SelectCase, len(open)+1)
+		cases[len(open)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.ctx.Done())}
+		for remaining > 0 {
+			// take from the highest priority channel that is ready
+			received := false
+			for i, c := range open {
+				if c == nil {
+					continue
+				}
+				select {
+				case t, ok := <-c:
+					received = true
+					if !ok {
+						open[i] = nil
+						remaining--
+					} else if !send(o.ctx, merged, t) {
+						return
+					}
+				default:
+				}
+				if received {
+					break
+				}
+			}
+			if received {
+				continue
+			}
+			// nothing is ready, so wait on all of them
+			for i, c := range open {
+				cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c)}
+			}
+			i, v, ok := reflect.Select(cases)
+			if i == len(open) {
+				return
+			}
+			if !ok {
+				open[i] = nil
+				remaining--
+				continue
+			}
+			t, _ := v.Interface().(T)
+			if !send(o.ctx, merged, t) {
+				return
+			}
+		}
+	}()
+	return merged
+}
+
+// Race returns the first value received from any of channels along with the
+// index of the channel it was received from. Channels that close without a
+// value are ignored; if all of them do, or the context given by WithContext is
+// done first, Race returns false. The losing channels are left unread, so pass
+// the same context to the stages producing them and cancel it to release them.
+func Race[T any](channels []<-chan T, opts ...Option) (T, int, bool) {
+	o := newOptions(opts)
+	// the last case waits for the context to be done
+	cases := make([]reflect.SelectCase, len(channels)+1)
+	for i, c := range channels {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c)}
+	}
+	cases[len(channels)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.ctx.Done())}
+	for remaining := len(channels); remaining > 0; remaining-- {
+		i, v, ok := reflect.Select(cases)
+		if i == len(channels) {
+			break
+		}
+		if ok {
+			t, _ := v.Interface().(T)
+			return t, i, true
+		}
+		// a nil channel is never ready
+		cases[i].Chan = reflect.Zero(cases[i].Chan.Type())
+	}
+	var zero T
+	return zero, -1, false
+}
@@ -0,0 +1,124 @@
+package channel
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"slices"
+	"testing"
+)
+
+func TestMergePriority(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		inputs [][]int
+		want   []int
+	}{
+		{
+			name:   "no_channels",
+			inputs: [][]int{},
+			want:   nil,
+		},
+		{
+			name:   "all_empty",
+			inputs: [][]int{{}, {}},
+			want:   nil,
+		},
+		{
+			name:   "high_before_low",
+			inputs: [][]int{{1, 2}, {10, 20}, {100}},
+			want:   []int{1, 2, 10, 20, 100},
+		},
+		{
+			name:   "high_empty",
+			inputs: [][]int{{}, {10, 20}},
+			want:   []int{10, 20},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var inputs []<-chan int
+			for _, input := range tc.inputs {
+				inputs = append(inputs, FromSlice(input))
+			}
+			got := ToSlice(MergePriority(inputs))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMergePriorityBlocking(t *testing.T) {
+	t.Parallel()
+
+	got := ToSlice(MergePriority([]<-chan int{Range(0, 5), Range(5, 10)}))
+	slices.Sort(got)
+	if diff := cmp.Diff(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		inputs    []<-chan int
+		canceled  bool
+		want      int
+		wantIndex int
+		wantOk    bool
+	}{
+		{
+			name:      "no_channels",
+			inputs:    nil,
+			want:      0,
+			wantIndex: -1,
+			wantOk:    false,
+		},
+		{
+			name:      "all_closed",
+			inputs:    []<-chan int{Of[int](), Of[int]()},
+			want:      0,
+			wantIndex: -1,
+			wantOk:    false,
+		},
+		{
+			name:      "canceled",
+			inputs:    []<-chan int{make(chan int)},
+			canceled:  true,
+			want:      0,
+			wantIndex: -1,
+			wantOk:    false,
+		},
+		{
+			name:      "first_closed",
+			inputs:    []<-chan int{Of[int](), make(chan int), Of(3, 4)},
+			want:      3,
+			wantIndex: 2,
+			wantOk:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.canceled {
+				cancel()
+			}
+			got, gotIndex, gotOk := Race(tc.inputs, WithContext(ctx))
+			if got != tc.want || gotIndex != tc.wantIndex || gotOk != tc.wantOk {
+				t.Errorf("unexpected result: got (%d, %d, %v), want (%d, %d, %v)",
+					got, gotIndex, gotOk, tc.want, tc.wantIndex, tc.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"expvar"
+	"sync"
+)
+
+// Expvar is a Provider publishing metrics with expvar, as a map from stage to
+// a map from metric name to value.
+type Expvar struct {
+	mu     sync.Mutex
+	stages *expvar.Map
+}
+
+// NewExpvar returns an Expvar publishing its metrics under name. Like
+// expvar.Publish, it panics if name is already in use.
+func NewExpvar(name string) *Expvar {
+	return NewExpvarMap(expvar.NewMap(name))
+}
+
+// NewExpvarMap returns an Expvar storing its metrics in m, which the caller
+// may publish or nest in another map as it sees fit.
+func NewExpvarMap(m *expvar.Map) *Expvar {
+	return &Expvar{stages: m}
+}
+
+func (e *Expvar) Counter(stage, name string) Counter {
+	return e.metric(stage, name)
+}
+
+func (e *Expvar) Gauge(stage, name string) Gauge {
+	return e.metric(stage, name)
+}
+
+func (e *Expvar) metric(stage, name string) *expvar.Int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	metrics, ok := e.stages.Get(stage).(*expvar.Map)
+	if !ok {
+		metrics = new(expvar.Map).Init()
+		e.stages.Set(stage, metrics)
+	}
+	metric, ok := metrics.Get(name).(*expvar.Int)
+	if !ok {
+		metric = new(expvar.Int)
+		metrics.Set(name, metric)
+	}
+	return metric
+}
@@ -0,0 +1,83 @@
+// Package metrics exposes per-stage metrics of channel pipelines through a
+// small Provider interface, so that they can be exported to any metrics
+// system. Implementations backed by expvar and Prometheus are included.
+package metrics
+
+import (
+	"github.com/lock14/functional/channel"
+)
+
+// Names of the metrics reported for every stage.
+const (
+	// Processed counts the values a stage has handled successfully.
+	Processed = "processed"
+	// Errored counts the values a stage has failed to handle.
+	Errored = "errored"
+	// InFlight is the number of values a stage is currently handling.
+	InFlight = "in_flight"
+	// QueueDepth is the number of values buffered in front of a stage.
+	QueueDepth = "queue_depth"
+)
+
+// Counter is a metric that only goes up.
+type Counter interface {
+	Add(delta int64)
+}
+
+// Gauge is a metric that can go up and down.
+type Gauge interface {
+	Add(delta int64)
+	Set(value int64)
+}
+
+// Provider returns the metrics of pipeline stages. Repeated calls with the
+// same stage and name must return the same metric, and metrics must be safe
+// for concurrent use.
+type Provider interface {
+	Counter(stage, name string) Counter
+	Gauge(stage, name string) Gauge
+}
+
+// Track wraps the function of a stage, such as one passed to
+// channel.MapWithErr or channel.ParallelMapWithErr, so that it reports the
+// Processed, Errored and InFlight metrics of stage to p.
+func Track[T, U any](p Provider, stage string, f func(T) (U, error)) func(T) (U, error) {
+	processed := p.Counter(stage, Processed)
+	errored := p.Counter(stage, Errored)
+	inFlight := p.Gauge(stage, InFlight)
+	return func(t T) (U, error) {
+		inFlight.Add(1)
+		defer inFlight.Add(-1)
+		u, err := f(t)
+		if err != nil {
+			errored.Add(1)
+		} else {
+			processed.Add(1)
+		}
+		return u, err
+	}
+}
+
+// TrackQueue forwards every value of ch unchanged while reporting the number
+// of values buffered in ch as the QueueDepth metric of stage to p. It is only
+// meaningful for a buffered ch, see channel.WithBuffer.
+func TrackQueue[T any](p Provider, stage string, ch <-chan T, opts ...channel.Option) <-chan T {
+	depth := p.Gauge(stage, QueueDepth)
+	return channel.Peek(ch, func(T) { depth.Set(int64(len(ch))) }, opts...)
+}
+
+// Observer returns a channel.Observer that reports the Processed metric of
+// every stage instrumented with channel.Instrument to p.
+func Observer(p Provider) channel.Observer {
+	return observer{p: p}
+}
+
+type observer struct {
+	p Provider
+}
+
+func (o observer) OnElement(stage string, _ channel.ElementStats) {
+	o.p.Counter(stage, Processed).Add(1)
+}
+
+func (o observer) OnClose(string, int64) {}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/channel"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"strings"
+	"testing"
+)
+
+// published returns the metrics stored in m by NewExpvarMap(m).
+func published(t *testing.T, m *expvar.Map) map[string]map[string]int64 {
+	t.Helper()
+	var got map[string]map[string]int64
+	if err := json.Unmarshal([]byte(m.String()), &got); err != nil {
+		t.Fatalf("got error %v, want %v", err, nil)
+	}
+	return got
+}
+
+func TestTrack(t *testing.T) {
+	t.Parallel()
+
+	m := new(expvar.Map).Init()
+	p := NewExpvarMap(m)
+	errOdd := errors.New("odd")
+	half := Track(p, "half", func(i int) (int, error) {
+		if i%2 != 0 {
+			return 0, errOdd
+		}
+		return i / 2, nil
+	})
+	mapped, errs := channel.MapWithErr(channel.Range(0, 5), half)
+	go channel.Drain(errs)
+	channel.Drain(mapped)
+	want := map[string]map[string]int64{"half": {Processed: 3, Errored: 2, InFlight: 0}}
+	if diff := cmp.Diff(published(t, m), want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestTrackQueue(t *testing.T) {
+	t.Parallel()
+
+	m := new(expvar.Map).Init()
+	p := NewExpvarMap(m)
+	input := channel.FromSlice([]int{1, 2, 3})
+	channel.Drain(TrackQueue(p, "source", input))
+	// the last value leaves nothing behind it in the buffer
+	want := map[string]map[string]int64{"source": {QueueDepth: 0}}
+	if diff := cmp.Diff(published(t, m), want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	m := new(expvar.Map).Init()
+	p := NewExpvarMap(m)
+	channel.Drain(channel.Instrument(channel.Range(0, 4), "source", Observer(p)))
+	want := map[string]map[string]int64{"source": {Processed: 4}}
+	if diff := cmp.Diff(published(t, m), want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestPrometheus(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	p := NewPrometheus(reg, "pipeline")
+	half := Track(p, "half", func(i int) (int, error) {
+		if i%2 != 0 {
+			return 0, errors.New("odd")
+		}
+		return i / 2, nil
+	})
+	mapped, errs := channel.MapWithErr(channel.Range(0, 5), half)
+	go channel.Drain(errs)
+	channel.Drain(mapped)
+	// a second provider on the same registry reuses the registered metrics
+	NewPrometheus(reg, "pipeline").Gauge("source", QueueDepth).Set(2)
+
+	want := `
+# HELP pipeline_errored_total Number of values a pipeline stage has failed to handle.
+# TYPE pipeline_errored_total counter
+pipeline_errored_total{stage="half"} 2
+# HELP pipeline_in_flight Number of values a pipeline stage is currently handling.
+# TYPE pipeline_in_flight gauge
+pipeline_in_flight{stage="half"} 0
+# HELP pipeline_processed_total Number of values a pipeline stage has handled successfully.
+# TYPE pipeline_processed_total counter
+pipeline_processed_total{stage="half"} 3
+# HELP pipeline_queue_depth Number of values buffered in front of a pipeline stage.
+# TYPE pipeline_queue_depth gauge
+pipeline_queue_depth{stage="source"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
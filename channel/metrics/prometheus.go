@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+)
+
+// Prometheus is a Provider registering its metrics with a Prometheus
+// registry. Every metric name becomes a vector named namespace_name, with one
+// series per stage under the "stage" label; counters also get the
+// conventional "_total" suffix.
+type Prometheus struct {
+	mu        sync.Mutex
+	reg       prometheus.Registerer
+	namespace string
+	counters  map[string]*prometheus.CounterVec
+	gauges    map[string]*prometheus.GaugeVec
+}
+
+// NewPrometheus returns a Prometheus registering its metrics with reg under
+// namespace. A metric that is already registered with reg is reused, but like
+// prometheus.MustRegister, registering fails with a panic if a different
+// metric of the same name is in the way.
+func NewPrometheus(reg prometheus.Registerer, namespace string) *Prometheus {
+	return &Prometheus{
+		reg:       reg,
+		namespace: namespace,
+		counters:  make(map[string]*prometheus.CounterVec),
+		gauges:    make(map[string]*prometheus.GaugeVec),
+	}
+}
+
+func (p *Prometheus) Counter(stage, name string) Counter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	vec, ok := p.counters[name]
+	if !ok {
+		vec = register(p.reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: p.namespace,
+			Name:      name + "_total",
+			Help:      help(name),
+		}, []string{"stage"}))
+		p.counters[name] = vec
+	}
+	return promCounter{vec.WithLabelValues(stage)}
+}
+
+func (p *Prometheus) Gauge(stage, name string) Gauge {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	vec, ok := p.gauges[name]
+	if !ok {
+		vec = register(p.reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: p.namespace,
+			Name:      name,
+			Help:      help(name),
+		}, []string{"stage"}))
+		p.gauges[name] = vec
+	}
+	return promGauge{vec.WithLabelValues(stage)}
+}
+
+// descriptions holds the help text of the metrics reported by this package.
+var descriptions = map[string]string{
+	Processed:  "Number of values a pipeline stage has handled successfully.",
+	Errored:    "Number of values a pipeline stage has failed to handle.",
+	InFlight:   "Number of values a pipeline stage is currently handling.",
+	QueueDepth: "Number of values buffered in front of a pipeline stage.",
+}
+
+func help(name string) string {
+	if description, ok := descriptions[name]; ok {
+		return description
+	}
+	return "Pipeline stage metric " + name + "."
+}
+
+// register registers c with reg, returning the collector that is already
+// registered in its place, if any.
+func register[C prometheus.Collector](reg prometheus.Registerer, c C) C {
+	if err := reg.Register(c); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return c
+}
+
+type promCounter struct {
+	c prometheus.Counter
+}
+
+func (c promCounter) Add(delta int64) {
+	c.c.Add(float64(delta))
+}
+
+type promGauge struct {
+	g prometheus.Gauge
+}
+
+func (g promGauge) Add(delta int64) {
+	g.g.Add(float64(delta))
+}
+
+func (g promGauge) Set(value int64) {
+	g.g.Set(float64(value))
+}
@@ -0,0 +1,160 @@
+package channel
+
+import (
+	"context"
+	"github.com/lock14/functional/clock"
+	"github.com/lock14/functional/try"
+	"runtime"
+	"runtime/debug"
+)
+
+// Option configures the behavior of the operators that accept it.
+type Option func(*options)
+
+type options struct {
+	bufferSize int
+	recover    bool
+	onPanic    func(error)
+	workers    int
+	semaphore  Semaphore
+	ctx        context.Context
+	clock      clock.Clock
+	runSize    int
+	tempDir    string
+}
+
+func newOptions(opts []Option) options {
+	o := options{workers: runtime.NumCPU(), ctx: context.Background(), clock: clock.Real}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBuffer sets the buffer size of the channel returned by an operator.
+// Operators return unbuffered channels by default, which makes each stage of
+// a pipeline wait for the next one in lock-step. A buffer lets a stage run
+// ahead of a slower consumer; see BenchmarkMap for the difference it makes.
+func WithBuffer(n int) Option {
+	return func(o *options) {
+		o.bufferSize = n
+	}
+}
+
+// WithWorkers sets the number of worker goroutines used by operators that run
+// on a bounded worker pool. Defaults to runtime.NumCPU().
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = max(n, 1)
+	}
+}
+
+// WithRecover makes the parallel operators recover from a panic in the
+// function they apply instead of crashing the process. The panic is converted
+// into a *PanicError, which the WithErr variants send on their error channel.
+// The other variants drop the element and pass the error to onPanic, if it is
+// not nil.
+func WithRecover(onPanic func(error)) Option {
+	return func(o *options) {
+		o.recover = true
+		o.onPanic = onPanic
+	}
+}
+
+// Semaphore is a weighted semaphore, such as *semaphore.Weighted from
+// golang.org/x/sync/semaphore.
+type Semaphore interface {
+	Acquire(ctx context.Context, n int64) error
+	Release(n int64)
+}
+
+// WithSemaphore makes the parallel operators hold a unit of sem while applying
+// their function to an element, so that several pipelines sharing sem also
+// share a global concurrency budget. The unit is released before the result is
+// sent downstream, so a stalled consumer does not hold on to the budget.
+func WithSemaphore(sem Semaphore) Option {
+	return func(o *options) {
+		o.semaphore = sem
+	}
+}
+
+// WithContext makes an operator stop as soon as ctx is done: it stops
+// receiving from its input, closes its output and exits without draining its
+// input. Pass the same context to every stage of a pipeline so that canceling
+// it releases all of their goroutines, however far downstream the pipeline
+// was abandoned.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// WithClock makes the time-based operators, such as Delay and WindowByTime,
+// measure time with c instead of the real clock, so that tests can advance
+// time deterministically with a clock.Fake.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithRunSize sets the maximum number of elements SortedExternal holds in
+// memory at once. Each full run is sorted and spilled to a temporary file.
+// Defaults to 65536.
+func WithRunSize(n int) Option {
+	return func(o *options) {
+		o.runSize = n
+	}
+}
+
+// WithTempDir sets the directory SortedExternal spills its runs to. Defaults
+// to os.TempDir().
+func WithTempDir(dir string) Option {
+	return func(o *options) {
+		o.tempDir = dir
+	}
+}
+
+// acquire acquires a unit of o.semaphore, if any, reporting false if o.ctx is
+// done first.
+func (o options) acquire() bool {
+	return o.semaphore == nil || o.semaphore.Acquire(o.ctx, 1) == nil
+}
+
+func (o options) release() {
+	if o.semaphore != nil {
+		o.semaphore.Release(1)
+	}
+}
+
+func makeChan[T any](o options) chan T {
+	return make(chan T, o.bufferSize)
+}
+
+// PanicError is the error a recovered panic is converted into.
+type PanicError = try.PanicError
+
+// protect calls f, converting a panic into a *PanicError if o enables it.
+func protect[T any](o options, f func() (T, error)) (t T, err error) {
+	if o.recover {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+	}
+	return f()
+}
+
+// protectOrReport is like protect for functions that cannot fail. It reports
+// whether f returned normally, passing any recovered panic to o.onPanic.
+func protectOrReport[T any](o options, f func() T) (T, bool) {
+	t, err := protect(o, func() (T, error) { return f(), nil })
+	if err != nil {
+		if o.onPanic != nil {
+			o.onPanic(err)
+		}
+		return t, false
+	}
+	return t, true
+}
@@ -4,15 +4,23 @@ import (
 	"sync"
 )
 
-func ParallelMap[T, U any](channel chan T, f func(T) U) chan U {
-	mapped := make(chan U)
+func ParallelMap[T, U any](channel <-chan T, f func(T) U, opts ...Option) <-chan U {
+	o := newOptions(opts)
+	mapped := makeChan[U](o)
 	go func() {
 		waitGroup := sync.WaitGroup{}
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
+			if !o.acquire() {
+				break
+			}
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				mapped <- f(t)
+				u, ok := protectOrReport(o, func() U { return f(t) })
+				o.release()
+				if ok {
+					send(o.ctx, mapped, u)
+				}
 			}()
 		}
 		waitGroup.Wait()
@@ -21,18 +29,34 @@ func ParallelMap[T, U any](channel chan T, f func(T) U) chan U {
 	return mapped
 }
 
-func ParallelFlatten[T any](channel chan chan T) chan T {
-	flat := make(chan T)
+// ParallelMapChunked is like ParallelMap but hands each worker chunkSize
+// values at a time, which amortizes the cost of the channel operations when f
+// is cheap. As with ParallelMap the mapped values are not ordered. When
+// recovering from panics, see WithRecover, a panic drops the rest of its chunk.
+func ParallelMapChunked[T, U any](channel <-chan T, f func(T) U, chunkSize int, opts ...Option) <-chan U {
+	mapChunk := func(ts []T) []U {
+		us := make([]U, len(ts))
+		for i, t := range ts {
+			us[i] = f(t)
+		}
+		return us
+	}
+	return FlattenSlices(ParallelMap(Chunk(channel, chunkSize, opts...), mapChunk, opts...), opts...)
+}
+
+func ParallelFlatten[T any](channel <-chan <-chan T, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	flat := makeChan[T](o)
 	go func() {
 		waitGroup := sync.WaitGroup{}
-		for c := range channel {
+		for c := range receiveAll(o.ctx, channel) {
+			waitGroup.Add(1)
 			go func() {
-				for t := range c {
-					waitGroup.Add(1)
-					go func() {
-						defer waitGroup.Done()
-						flat <- t
-					}()
+				defer waitGroup.Done()
+				for t := range receiveAll(o.ctx, c) {
+					if !send(o.ctx, flat, t) {
+						return
+					}
 				}
 			}()
 		}
@@ -42,20 +66,26 @@ func ParallelFlatten[T any](channel chan chan T) chan T {
 	return flat
 }
 
-func ParallelFlatMap[T, U any](channel chan T, f func(T) chan U) chan U {
-	return ParallelFlatten(ParallelMap(channel, f))
+func ParallelFlatMap[T, U any](channel <-chan T, f func(T) <-chan U, opts ...Option) <-chan U {
+	return ParallelFlatten(ParallelMap(channel, f, opts...), opts...)
 }
 
-func ParallelFilter[T any](channel chan T, p func(T) bool) chan T {
-	filtered := make(chan T)
+func ParallelFilter[T any](channel <-chan T, p func(T) bool, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	filtered := makeChan[T](o)
 	go func() {
 		waitGroup := sync.WaitGroup{}
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
+			if !o.acquire() {
+				break
+			}
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				if p(t) {
-					filtered <- t
+				ok, _ := protectOrReport(o, func() bool { return p(t) })
+				o.release()
+				if ok {
+					send(o.ctx, filtered, t)
 				}
 			}()
 		}
@@ -64,3 +94,53 @@ func ParallelFilter[T any](channel chan T, p func(T) bool) chan T {
 	}()
 	return filtered
 }
+
+// ParallelForEach calls consumer for every value of channel using a bounded
+// pool of workers, see WithWorkers. It blocks until channel is drained or the
+// context given by WithContext is done.
+func ParallelForEach[T any](channel <-chan T, consumer func(T), opts ...Option) {
+	o := newOptions(opts)
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < o.workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for t := range receiveAll(o.ctx, channel) {
+				if !o.acquire() {
+					return
+				}
+				protectOrReport(o, func() struct{} { consumer(t); return struct{}{} })
+				o.release()
+			}
+		}()
+	}
+	waitGroup.Wait()
+}
+
+// ParallelReduce folds the values of channel concurrently, with each worker
+// accumulating its own partial result starting from identity, and then
+// combines the partial results. Since values are distributed among workers
+// nondeterministically, accumulate and combine must be associative and
+// commutative, and identity must be an identity element of combine.
+func ParallelReduce[T, U any](channel <-chan T, identity U, accumulate func(U, T) U, combine func(U, U) U, opts ...Option) U {
+	o := newOptions(opts)
+	partials := make([]U, o.workers)
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < o.workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			partial := identity
+			for t := range receiveAll(o.ctx, channel) {
+				partial = accumulate(partial, t)
+			}
+			partials[i] = partial
+		}()
+	}
+	waitGroup.Wait()
+	result := identity
+	for _, partial := range partials {
+		result = combine(result, partial)
+	}
+	return result
+}
@@ -0,0 +1,234 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMapWithRecover(t *testing.T) {
+	t.Parallel()
+
+	panics := atomic.Int64{}
+	mapped := ParallelMap(FromSlice([]int{1, 2, 3, 4}), func(i int) int {
+		if i == 3 {
+			panic("bad element")
+		}
+		return i * 2
+	}, WithRecover(func(err error) {
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) {
+			t.Errorf("expected a *PanicError but got %T", err)
+		}
+		panics.Add(1)
+	}))
+	got := ToSlice(mapped)
+	slices.Sort(got)
+	if diff := cmp.Diff(got, []int{2, 4, 8}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if got := panics.Load(); got != 1 {
+		t.Errorf("unexpected number of panics: got %d, want 1", got)
+	}
+}
+
+func TestParallelMapChunked(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		input     []int
+		chunkSize int
+		want      []int
+	}{
+		{
+			name:      "empty",
+			input:     []int{},
+			chunkSize: 3,
+			want:      nil,
+		},
+		{
+			name:      "partial_chunk",
+			input:     []int{1, 2},
+			chunkSize: 3,
+			want:      []int{2, 4},
+		},
+		{
+			name:      "several_chunks",
+			input:     []int{1, 2, 3, 4, 5, 6, 7},
+			chunkSize: 3,
+			want:      []int{2, 4, 6, 8, 10, 12, 14},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ToSlice(ParallelMapChunked(FromSlice(tc.input), func(i int) int { return i * 2 }, tc.chunkSize, WithWorkers(2)))
+			slices.Sort(got)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParallelMapWithErrWithRecover(t *testing.T) {
+	t.Parallel()
+
+	errBad := errors.New("bad element")
+	mapped, errs := ParallelMapWithErr(FromSlice([]int{1, 2, 3, 4}), func(i int) (int, error) {
+		if i == 3 {
+			panic(errBad)
+		}
+		return i * 2, nil
+	}, WithRecover(nil))
+	var gotErrs []error
+	done := make(chan struct{})
+	go func() {
+		gotErrs = ToSlice(errs)
+		close(done)
+	}()
+	got := ToSlice(mapped)
+	<-done
+	slices.Sort(got)
+	if diff := cmp.Diff(got, []int{2, 4, 8}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if len(gotErrs) != 1 || !errors.Is(gotErrs[0], errBad) {
+		t.Errorf("expected a single error wrapping %v but got %v", errBad, gotErrs)
+	}
+}
+
+func TestParallelForEach(t *testing.T) {
+	t.Parallel()
+
+	sum := atomic.Int64{}
+	ParallelForEach(Range(1, 101), func(i int) { sum.Add(int64(i)) }, WithWorkers(4))
+	if got := sum.Load(); got != 5050 {
+		t.Errorf("unexpected sum: got %d, want 5050", got)
+	}
+}
+
+func TestParallelForEachWithErr(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+	err := ParallelForEachWithErr(Range(0, 10), func(i int) error {
+		if i%2 == 1 {
+			return errOdd
+		}
+		return nil
+	}, WithWorkers(3))
+	if !errors.Is(err, errOdd) {
+		t.Errorf("expected error wrapping %v but got %v", errOdd, err)
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected a joined error but got %T", err)
+	}
+	if count := len(joined.Unwrap()); count != 5 {
+		t.Errorf("unexpected number of errors: got %d, want 5", count)
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		input   []int
+		workers int
+		want    int
+	}{
+		{
+			name:    "empty",
+			input:   []int{},
+			workers: 4,
+			want:    0,
+		},
+		{
+			name:    "one_worker",
+			input:   []int{1, 2, 3, 4, 5},
+			workers: 1,
+			want:    55,
+		},
+		{
+			name:    "many_workers",
+			input:   []int{1, 2, 3, 4, 5},
+			workers: 4,
+			want:    55,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sumOfSquares := func(acc, i int) int { return acc + i*i }
+			add := func(a, b int) int { return a + b }
+			got := ParallelReduce(FromSlice(tc.input), 0, sumOfSquares, add, WithWorkers(tc.workers))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// countingSemaphore is a Semaphore that records the maximum number of units
+// held at once.
+type countingSemaphore struct {
+	units   chan struct{}
+	held    atomic.Int64
+	maxHeld atomic.Int64
+}
+
+func newCountingSemaphore(n int) *countingSemaphore {
+	return &countingSemaphore{units: make(chan struct{}, n)}
+}
+
+func (s *countingSemaphore) Acquire(ctx context.Context, n int64) error {
+	for i := int64(0); i < n; i++ {
+		select {
+		case s.units <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	held := s.held.Add(n)
+	for {
+		maxHeld := s.maxHeld.Load()
+		if held <= maxHeld || s.maxHeld.CompareAndSwap(maxHeld, held) {
+			return nil
+		}
+	}
+}
+
+func (s *countingSemaphore) Release(n int64) {
+	s.held.Add(-n)
+	for i := int64(0); i < n; i++ {
+		<-s.units
+	}
+}
+
+func TestParallelWithSemaphore(t *testing.T) {
+	t.Parallel()
+
+	sem := newCountingSemaphore(2)
+	slow := func(i int) int {
+		time.Sleep(time.Millisecond)
+		return i
+	}
+	mapped := ParallelMap(Range(0, 20), slow, WithSemaphore(sem))
+	filtered := ParallelFilter(Range(0, 20), func(i int) bool { return slow(i)%2 == 0 }, WithSemaphore(sem))
+	got := len(ToSlice(mapped)) + len(ToSlice(filtered))
+	if got != 30 {
+		t.Errorf("unexpected number of results: got %d, want 30", got)
+	}
+	if maxHeld := sem.maxHeld.Load(); maxHeld > 2 {
+		t.Errorf("semaphore was exceeded: %d units held at once", maxHeld)
+	}
+}
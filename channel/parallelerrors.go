@@ -1,23 +1,30 @@
 package channel
 
 import (
+	"errors"
+	"slices"
 	"sync"
 )
 
-func ParallelMapWithErr[T, U any](channel chan T, f func(T) (U, error)) (chan U, chan error) {
-	mapped := make(chan U)
+func ParallelMapWithErr[T, U any](channel <-chan T, f func(T) (U, error), opts ...Option) (<-chan U, <-chan error) {
+	o := newOptions(opts)
+	mapped := makeChan[U](o)
 	errs := make(chan error)
 	go func() {
 		waitGroup := sync.WaitGroup{}
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
+			if !o.acquire() {
+				break
+			}
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				u, err := f(t)
+				u, err := protect(o, func() (U, error) { return f(t) })
+				o.release()
 				if err != nil {
-					errs <- err
+					send(o.ctx, errs, err)
 				} else {
-					mapped <- u
+					send(o.ctx, mapped, u)
 				}
 			}()
 		}
@@ -28,25 +35,30 @@ func ParallelMapWithErr[T, U any](channel chan T, f func(T) (U, error)) (chan U,
 	return mapped, errs
 }
 
-func ParallelFlatMapWithErr[T, U any](channel chan T, f func(T) (chan U, error)) (chan U, chan error) {
-	channels, errs := ParallelMapWithErr(channel, f)
-	return ParallelFlatten(channels), errs
+func ParallelFlatMapWithErr[T, U any](channel <-chan T, f func(T) (<-chan U, error), opts ...Option) (<-chan U, <-chan error) {
+	channels, errs := ParallelMapWithErr(channel, f, opts...)
+	return ParallelFlatten(channels, opts...), errs
 }
 
-func ParallelFilterWithErr[T any](channel chan T, p func(T) (bool, error)) (chan T, chan error) {
-	filtered := make(chan T)
+func ParallelFilterWithErr[T any](channel <-chan T, p func(T) (bool, error), opts ...Option) (<-chan T, <-chan error) {
+	o := newOptions(opts)
+	filtered := makeChan[T](o)
 	errs := make(chan error)
 	go func() {
 		waitGroup := sync.WaitGroup{}
-		for t := range channel {
+		for t := range receiveAll(o.ctx, channel) {
+			if !o.acquire() {
+				break
+			}
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				ok, err := p(t)
+				ok, err := protect(o, func() (bool, error) { return p(t) })
+				o.release()
 				if err != nil {
-					errs <- err
+					send(o.ctx, errs, err)
 				} else if ok {
-					filtered <- t
+					send(o.ctx, filtered, t)
 				}
 			}()
 		}
@@ -56,3 +68,29 @@ func ParallelFilterWithErr[T any](channel chan T, p func(T) (bool, error)) (chan
 	}()
 	return filtered, errs
 }
+
+// ParallelForEachWithErr is like ParallelForEach but returns the errors
+// returned by consumer joined together, or nil if there were none.
+func ParallelForEachWithErr[T any](channel <-chan T, consumer func(T) error, opts ...Option) error {
+	o := newOptions(opts)
+	errs := make([][]error, o.workers)
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < o.workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for t := range receiveAll(o.ctx, channel) {
+				if !o.acquire() {
+					return
+				}
+				_, err := protect(o, func() (struct{}, error) { return struct{}{}, consumer(t) })
+				o.release()
+				if err != nil {
+					errs[i] = append(errs[i], err)
+				}
+			}
+		}()
+	}
+	waitGroup.Wait()
+	return errors.Join(slices.Concat(errs...)...)
+}
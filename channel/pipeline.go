@@ -0,0 +1,191 @@
+package channel
+
+import (
+	"context"
+	"sync"
+)
+
+// Pipeline is a builder for a chain of channel stages. Stages are only wired
+// together when Run is called, at which point every goroutine started by the
+// pipeline is tracked so that the whole pipeline can be torn down as soon as
+// a stage fails or the context is canceled.
+//
+// Stages that keep the element type are methods on Pipeline. Since methods
+// cannot introduce type parameters, stages that change the element type are
+// the functions PipelineMap and PipelineBatch.
+type Pipeline[T any] struct {
+	workers int
+	build   func(r *pipelineRun) <-chan T
+}
+
+type pipelineRun struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+func (r *pipelineRun) fail(err error) {
+	r.errOnce.Do(func() {
+		r.err = err
+		r.cancel()
+	})
+}
+
+func NewPipeline[T any](source <-chan T) *Pipeline[T] {
+	return &Pipeline[T]{
+		workers: 1,
+		build:   func(*pipelineRun) <-chan T { return source },
+	}
+}
+
+// Parallel sets the number of workers used by the stages added after it.
+// Parallel stages do not preserve the order of elements.
+func (p *Pipeline[T]) Parallel(workers int) *Pipeline[T] {
+	return &Pipeline[T]{workers: max(workers, 1), build: p.build}
+}
+
+func (p *Pipeline[T]) Filter(pred func(T) bool) *Pipeline[T] {
+	return addStage(p, func(t T) (T, bool, error) { return t, pred(t), nil })
+}
+
+func (p *Pipeline[T]) Peek(consumer func(T)) *Pipeline[T] {
+	return addStage(p, func(t T) (T, bool, error) {
+		consumer(t)
+		return t, true, nil
+	})
+}
+
+// Run wires the stages together and passes every element that reaches the
+// end of the pipeline to sink. It blocks until the pipeline is exhausted, a
+// stage or sink returns an error, or ctx is done, and returns only after all
+// goroutines started by the pipeline have exited. The source channel is not
+// drained when the pipeline stops early.
+func (p *Pipeline[T]) Run(ctx context.Context, sink func(T) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	r := &pipelineRun{ctx: ctx, cancel: cancel}
+	out := p.build(r)
+	completed := false
+	for !completed && ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case t, ok := <-out:
+			if !ok {
+				completed = true
+			} else if err := sink(t); err != nil {
+				r.fail(err)
+			}
+		}
+	}
+	// a failing stage closes out as it exits, so the pipeline may look
+	// completed even though it was torn down by the failure
+	if !completed {
+		r.fail(ctx.Err())
+	}
+	cancel()
+	r.wg.Wait()
+	return r.err
+}
+
+func PipelineMap[T, U any](p *Pipeline[T], f func(T) (U, error)) *Pipeline[U] {
+	return addStage(p, func(t T) (U, bool, error) {
+		u, err := f(t)
+		return u, true, err
+	})
+}
+
+// PipelineBatch groups elements into slices of up to size elements. The last
+// batch may be smaller.
+func PipelineBatch[T any](p *Pipeline[T], size int) *Pipeline[[]T] {
+	return &Pipeline[[]T]{
+		workers: p.workers,
+		build: func(r *pipelineRun) <-chan []T {
+			in := p.build(r)
+			out := make(chan []T)
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				defer close(out)
+				var batch []T
+				emit := func() bool {
+					select {
+					case out <- batch:
+						batch = nil
+						return true
+					case <-r.ctx.Done():
+						return false
+					}
+				}
+				for {
+					select {
+					case <-r.ctx.Done():
+						return
+					case t, ok := <-in:
+						if !ok {
+							if len(batch) > 0 {
+								emit()
+							}
+							return
+						}
+						batch = append(batch, t)
+						if len(batch) == size && !emit() {
+							return
+						}
+					}
+				}
+			}()
+			return out
+		},
+	}
+}
+
+func addStage[T, U any](p *Pipeline[T], f func(T) (U, bool, error)) *Pipeline[U] {
+	return &Pipeline[U]{
+		workers: p.workers,
+		build: func(r *pipelineRun) <-chan U {
+			in := p.build(r)
+			out := make(chan U)
+			workers := sync.WaitGroup{}
+			for i := 0; i < p.workers; i++ {
+				workers.Add(1)
+				r.wg.Add(1)
+				go func() {
+					defer r.wg.Done()
+					defer workers.Done()
+					for {
+						select {
+						case <-r.ctx.Done():
+							return
+						case t, ok := <-in:
+							if !ok {
+								return
+							}
+							u, keep, err := f(t)
+							if err != nil {
+								r.fail(err)
+								return
+							}
+							if !keep {
+								continue
+							}
+							select {
+							case out <- u:
+							case <-r.ctx.Done():
+								return
+							}
+						}
+					}
+				}()
+			}
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				workers.Wait()
+				close(out)
+			}()
+			return out
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"strconv"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	errBad := errors.New("bad element")
+	cases := []struct {
+		name    string
+		input   []int
+		workers int
+		mapFunc func(int) (string, error)
+		want    [][]string
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			input:   []int{},
+			workers: 1,
+			mapFunc: func(i int) (string, error) { return strconv.Itoa(i), nil },
+			want:    nil,
+		},
+		{
+			name:    "many",
+			input:   []int{1, 2, 3, 4, 5, 6, 7},
+			workers: 1,
+			mapFunc: func(i int) (string, error) { return strconv.Itoa(i), nil },
+			want:    [][]string{{"2", "4"}, {"6"}},
+		},
+		{
+			name:    "parallel",
+			input:   []int{1, 2, 3, 4, 5, 6, 7},
+			workers: 4,
+			mapFunc: func(i int) (string, error) { return strconv.Itoa(i), nil },
+			want:    [][]string{{"2", "4", "6"}},
+		},
+		{
+			name:    "error",
+			input:   []int{1, 2, 3, 4, 5, 6, 7},
+			workers: 4,
+			mapFunc: func(i int) (string, error) {
+				if i == 4 {
+					return "", errBad
+				}
+				return strconv.Itoa(i), nil
+			},
+			wantErr: errBad,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			batchSize := 2
+			if tc.workers > 1 {
+				batchSize = 3
+			}
+			evens := NewPipeline(FromSlice(tc.input)).
+				Parallel(tc.workers).
+				Filter(func(i int) bool { return i%2 == 0 })
+			batched := PipelineBatch(PipelineMap(evens, tc.mapFunc), batchSize)
+			var got [][]string
+			err := batched.Run(context.Background(), func(batch []string) error {
+				got = append(got, batch)
+				return nil
+			})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			sortBatches := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if diff := cmp.Diff(got, tc.want, sortBatches); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPipelineCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	generator := GenerateCtx(ctx, (&StatefulSupplier{}).Supply)
+	count := 0
+	err := NewPipeline(generator).Run(ctx, func(int) error {
+		count++
+		if count == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestPipelineLastStageFails(t *testing.T) {
+	t.Parallel()
+
+	errLast := errors.New("last")
+	// the failure closes the output of the stage while canceling the run, so
+	// repeat to exercise both orders in which Run can observe them
+	for range 100 {
+		var got []int
+		err := PipelineMap(NewPipeline(Range(0, 3)), func(i int) (int, error) {
+			if i == 2 {
+				return 0, errLast
+			}
+			return i, nil
+		}).Run(context.Background(), func(i int) error {
+			got = append(got, i)
+			return nil
+		})
+		if !errors.Is(err, errLast) {
+			t.Fatalf("got error %v, want %v", err, errLast)
+		}
+		if diff := cmp.Diff(got, []int{0, 1}); diff != "" {
+			t.Fatalf("unexpected result (-got, +want): %s", diff)
+		}
+	}
+}
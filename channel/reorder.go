@@ -0,0 +1,93 @@
+package channel
+
+import (
+	"container/heap"
+)
+
+// Reorder fixes up a slightly out of order channel by buffering up to
+// bufferSize values and always emitting the smallest buffered value according
+// to less. A value that arrives more than bufferSize positions after a
+// greater one can still be emitted out of order.
+func Reorder[T any](channel <-chan T, bufferSize int, less func(a, b T) bool, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	reordered := makeChan[T](o)
+	go func() {
+		defer close(reordered)
+		buf := &lessHeap[T]{less: less}
+		for t := range receiveAll(o.ctx, channel) {
+			heap.Push(buf, t)
+			if buf.Len() > bufferSize {
+				if !send(o.ctx, reordered, heap.Pop(buf).(T)) {
+					return
+				}
+			}
+		}
+		for buf.Len() > 0 {
+			if !send(o.ctx, reordered, heap.Pop(buf).(T)) {
+				return
+			}
+		}
+	}()
+	return reordered
+}
+
+// PriorityBuffer buffers up to capacity values of channel and, whenever its
+// consumer is ready, emits the smallest buffered value according to less. Unlike
+// Reorder it does not wait for the buffer to fill, so urgent values overtake
+// the values still waiting in the buffer when the consumer falls behind, and
+// values pass straight through when it keeps up. A capacity less than 1 is
+// treated as 1.
+func PriorityBuffer[T any](channel <-chan T, less func(a, b T) bool, capacity int, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	prioritized := makeChan[T](o)
+	capacity = max(capacity, 1)
+	go func() {
+		defer close(prioritized)
+		buf := &lessHeap[T]{less: less}
+		in := channel
+		for in != nil || buf.Len() > 0 {
+			// only receive while there is room, and only send what is buffered
+			receiveFrom := in
+			if buf.Len() >= capacity {
+				receiveFrom = nil
+			}
+			var out chan T
+			var next T
+			if buf.Len() > 0 {
+				out, next = prioritized, buf.items[0]
+			}
+			select {
+			case t, ok := <-receiveFrom:
+				if !ok {
+					in = nil
+				} else {
+					heap.Push(buf, t)
+				}
+			case out <- next:
+				heap.Pop(buf)
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return prioritized
+}
+
+// lessHeap is a heap.Interface ordered by less.
+type lessHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *lessHeap[T]) Len() int           { return len(h.items) }
+func (h *lessHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *lessHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *lessHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+func (h *lessHeap[T]) Pop() any {
+	var zero T
+	n := len(h.items)
+	x := h.items[n-1]
+	h.items[n-1] = zero
+	h.items = h.items[:n-1]
+	return x
+}
@@ -0,0 +1,126 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestReorder(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		input      []int
+		bufferSize int
+		want       []int
+	}{
+		{
+			name:       "empty",
+			input:      []int{},
+			bufferSize: 2,
+			want:       nil,
+		},
+		{
+			name:       "no_buffer",
+			input:      []int{2, 1, 3},
+			bufferSize: 0,
+			want:       []int{2, 1, 3},
+		},
+		{
+			name:       "within_tolerance",
+			input:      []int{2, 1, 4, 3, 6, 5},
+			bufferSize: 1,
+			want:       []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:       "beyond_tolerance",
+			input:      []int{4, 1, 2, 3},
+			bufferSize: 1,
+			want:       []int{1, 2, 3, 4},
+		},
+		{
+			name:       "too_late",
+			input:      []int{2, 3, 4, 1},
+			bufferSize: 1,
+			want:       []int{2, 3, 1, 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ToSlice(Reorder(FromSlice(tc.input), tc.bufferSize, func(a, b int) bool { return a < b }))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPriorityBuffer(t *testing.T) {
+	t.Parallel()
+
+	// receive is a step of a script that receives a value instead of sending one
+	const receive = -1
+	cases := []struct {
+		name     string
+		script   []int
+		capacity int
+		want     []int
+	}{
+		{
+			name:     "empty",
+			script:   []int{},
+			capacity: 2,
+			want:     nil,
+		},
+		{
+			name:     "fits_in_buffer",
+			script:   []int{3, 1, 2},
+			capacity: 3,
+			want:     []int{1, 2, 3},
+		},
+		{
+			name:     "urgent_overtakes",
+			script:   []int{5, 6, receive, 1, receive},
+			capacity: 3,
+			want:     []int{5, 1, 6},
+		},
+		{
+			name:     "bounded_window",
+			script:   []int{5, 4, receive, 3, receive, 2, receive, 1},
+			capacity: 2,
+			want:     []int{4, 3, 2, 1, 5},
+		},
+		{
+			name:     "non_positive_capacity",
+			script:   []int{2, receive, 1, receive, 3},
+			capacity: 0,
+			want:     []int{2, 1, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			// a single goroutine sending and receiving leaves PriorityBuffer
+			// only one step it can take at a time, making the order deterministic
+			input := make(chan int)
+			prioritized := PriorityBuffer(input, func(a, b int) bool { return a < b }, tc.capacity)
+			var got []int
+			for _, step := range tc.script {
+				if step == receive {
+					got = append(got, <-prioritized)
+				} else {
+					input <- step
+				}
+			}
+			close(input)
+			got = ToSliceInto(prioritized, got)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
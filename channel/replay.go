@@ -0,0 +1,118 @@
+package channel
+
+import (
+	"slices"
+	"sync"
+)
+
+// Replay records the most recent values of a channel so that subscribers that
+// attach late still receive them. Every subscriber first receives the
+// recorded history and then the live values. Each subscriber has its own
+// queue, so a slow subscriber does not hold up the others. The queue is
+// unbounded: a subscriber that stops receiving keeps every live value in
+// memory until it resumes or its context is done, at which point it is
+// unsubscribed.
+type Replay[T any] struct {
+	mu          sync.Mutex
+	history     *ring[T]
+	subscribers []*replaySubscriber[T]
+	closed      bool
+}
+
+type replaySubscriber[T any] struct {
+	c    chan T
+	done chan struct{}
+}
+
+// NewReplay starts recording the values of channel, retaining at most
+// capacity of them for late subscribers.
+func NewReplay[T any](channel <-chan T, capacity int, opts ...Option) *Replay[T] {
+	o := newOptions(opts)
+	r := &Replay[T]{history: newRing[T](capacity)}
+	go func() {
+		for t := range receiveAll(o.ctx, channel) {
+			r.mu.Lock()
+			if r.history.full() && r.history.len() > 0 {
+				r.history.pop()
+			}
+			if !r.history.full() {
+				r.history.push(t)
+			}
+			for _, s := range r.subscribers {
+				select {
+				case s.c <- t:
+				case <-s.done:
+				}
+			}
+			r.mu.Unlock()
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.closed = true
+		for _, s := range r.subscribers {
+			close(s.c)
+		}
+		r.subscribers = nil
+	}()
+	return r
+}
+
+// Subscribe returns a channel that receives the recorded history followed by
+// every value received from now on. It is closed once the recorded channel is
+// closed and all values have been delivered. Values wait in an unbounded queue
+// until they are received, so pass WithContext to stop a subscriber that is
+// no longer receiving.
+func (r *Replay[T]) Subscribe(opts ...Option) <-chan T {
+	o := newOptions(opts)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue := make([]T, 0, r.history.len())
+	for i := 0; i < r.history.len(); i++ {
+		queue = append(queue, r.history.at(i))
+	}
+	var in chan T
+	s := &replaySubscriber[T]{done: make(chan struct{})}
+	if !r.closed {
+		in = make(chan T)
+		s.c = in
+		r.subscribers = append(r.subscribers, s)
+	}
+	out := makeChan[T](o)
+	go func() {
+		defer close(out)
+		for in != nil || len(queue) > 0 {
+			var sendTo chan T
+			var next T
+			if len(queue) > 0 {
+				sendTo, next = out, queue[0]
+			}
+			select {
+			case t, ok := <-in:
+				if ok {
+					queue = append(queue, t)
+				} else {
+					in = nil
+				}
+			case sendTo <- next:
+				queue = queue[1:]
+			case <-o.ctx.Done():
+				if in != nil {
+					r.unsubscribe(s)
+				}
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// unsubscribe stops the recorder from delivering values to s.
+func (r *Replay[T]) unsubscribe(s *replaySubscriber[T]) {
+	// unblock the recorder if it is delivering to s before waiting for the lock
+	close(s.done)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i := slices.Index(r.subscribers, s); i >= 0 {
+		r.subscribers = slices.Delete(r.subscribers, i, i+1)
+	}
+}
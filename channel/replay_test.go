@@ -0,0 +1,102 @@
+package channel
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/functest"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		capacity int
+		early    []int
+		late     []int
+		wantLate []int
+	}{
+		{
+			name:     "no_history",
+			capacity: 0,
+			early:    []int{1, 2, 3},
+			late:     []int{4, 5},
+			wantLate: []int{4, 5},
+		},
+		{
+			name:     "partial_history",
+			capacity: 2,
+			early:    []int{1, 2, 3},
+			late:     []int{4, 5},
+			wantLate: []int{2, 3, 4, 5},
+		},
+		{
+			name:     "full_history",
+			capacity: 10,
+			early:    []int{1, 2, 3},
+			late:     []int{4, 5},
+			wantLate: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			source := make(chan int)
+			replay := NewReplay(source, tc.capacity)
+			first := replay.Subscribe()
+			for _, i := range tc.early {
+				source <- i
+			}
+			// wait for the first subscriber to see every early value so
+			// they are guaranteed to be recorded
+			var gotFirst []int
+			for range tc.early {
+				gotFirst = append(gotFirst, <-first)
+			}
+			second := replay.Subscribe()
+			for _, i := range tc.late {
+				source <- i
+			}
+			close(source)
+			gotFirst = append(gotFirst, ToSlice(first)...)
+			gotSecond := ToSlice(second)
+			if diff := cmp.Diff(gotFirst, append(tc.early, tc.late...)); diff != "" {
+				t.Errorf("unexpected result for first subscriber (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(gotSecond, tc.wantLate); diff != "" {
+				t.Errorf("unexpected result for second subscriber (-got, +want): %s", diff)
+			}
+			// subscribing after the source is closed only replays history
+			third := ToSlice(replay.Subscribe())
+			if len(third) > tc.capacity {
+				t.Errorf("expected at most %d replayed values but got %v", tc.capacity, third)
+			}
+		})
+	}
+}
+
+func TestReplayUnsubscribesCanceledSubscriber(t *testing.T) {
+	functest.RequireNoGoroutineLeak(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source, _ := naturals(WithContext(ctx))
+	replay := NewReplay(source, 2, WithContext(ctx))
+	subscriberCtx, cancelSubscriber := context.WithCancel(context.Background())
+	receiveOne(replay.Subscribe(WithContext(subscriberCtx)))
+	cancelSubscriber()
+	// the source never ends, so the subscriber must remove itself
+	for {
+		replay.mu.Lock()
+		subscribers := len(replay.subscribers)
+		replay.mu.Unlock()
+		if subscribers == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+}
@@ -0,0 +1,30 @@
+package channel
+
+import (
+	"github.com/lock14/functional/iterator"
+	"io"
+)
+
+// WriteWith writes format(t) for every value t of channel to w, returning once
+// channel is closed or the context given by WithContext is done. Writes are
+// buffered and flushed before returning, along with w itself if it has a Flush
+// method, like *bufio.Writer. If writing fails, the error is returned after
+// flushing what was written before it. The rest of channel is then left
+// unread, so pass the same context to the stages producing channel and cancel
+// it to release them.
+func WriteWith[T any](w io.Writer, channel <-chan T, format func(T) []byte, opts ...Option) error {
+	o := newOptions(opts)
+	return iterator.WriteWith(w, receiveAll(o.ctx, channel), format)
+}
+
+// WriteLines writes every value of channel to w as a line, see WriteWith.
+func WriteLines[S ~string](w io.Writer, channel <-chan S, opts ...Option) error {
+	return WriteWith(w, channel, func(s S) []byte { return append([]byte(s), '\n') }, opts...)
+}
+
+// WriteJSONLines writes every value of channel to w as a line of JSON, see
+// WriteWith. A value that cannot be encoded stops writing like a failed write.
+func WriteJSONLines[T any](w io.Writer, channel <-chan T, opts ...Option) error {
+	o := newOptions(opts)
+	return iterator.WriteJSONLines(w, receiveAll(o.ctx, channel))
+}
@@ -0,0 +1,81 @@
+package channel
+
+// Split routes every value of channel to the first returned channel if p holds
+// for it and to the second otherwise. Each output buffers the values its
+// consumer has not received yet, so the two can be consumed at different
+// rates, or one after the other. An output that is never consumed therefore
+// holds on to all of its values. Both channels are closed once channel is
+// closed and their buffered values have been received.
+func Split[T any](channel <-chan T, p func(T) bool, opts ...Option) (<-chan T, <-chan T) {
+	o := newOptions(opts)
+	matched := makeChan[T](o)
+	unmatched := makeChan[T](o)
+	go func() {
+		matchedOutput := &splitOutput[T]{c: matched}
+		unmatchedOutput := &splitOutput[T]{c: unmatched}
+		defer matchedOutput.close()
+		defer unmatchedOutput.close()
+		in := channel
+		for in != nil || matchedOutput.c != nil || unmatchedOutput.c != nil {
+			select {
+			case t, ok := <-in:
+				if !ok {
+					in = nil
+				} else if p(t) {
+					matchedOutput.queue = append(matchedOutput.queue, t)
+				} else {
+					unmatchedOutput.queue = append(unmatchedOutput.queue, t)
+				}
+			case matchedOutput.out() <- matchedOutput.next():
+				matchedOutput.queue = matchedOutput.queue[1:]
+			case unmatchedOutput.out() <- unmatchedOutput.next():
+				unmatchedOutput.queue = unmatchedOutput.queue[1:]
+			case <-o.ctx.Done():
+				return
+			}
+			if in == nil {
+				// nothing more will be queued, so drained outputs are done
+				matchedOutput.closeIfDrained()
+				unmatchedOutput.closeIfDrained()
+			}
+		}
+	}()
+	return matched, unmatched
+}
+
+// splitOutput is an output of Split along with the values queued for it. c is
+// nil once it has been closed.
+type splitOutput[T any] struct {
+	c     chan T
+	queue []T
+}
+
+// out returns c if there is a value to send on it, and nil otherwise so that
+// sending blocks forever.
+func (s *splitOutput[T]) out() chan T {
+	if len(s.queue) == 0 {
+		return nil
+	}
+	return s.c
+}
+
+func (s *splitOutput[T]) next() T {
+	var zero T
+	if len(s.queue) == 0 {
+		return zero
+	}
+	return s.queue[0]
+}
+
+func (s *splitOutput[T]) closeIfDrained() {
+	if len(s.queue) == 0 {
+		s.close()
+	}
+}
+
+func (s *splitOutput[T]) close() {
+	if s.c != nil {
+		close(s.c)
+		s.c = nil
+	}
+}
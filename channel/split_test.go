@@ -0,0 +1,52 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		input         []int
+		wantMatched   []int
+		wantUnmatched []int
+	}{
+		{
+			name:          "empty",
+			input:         []int{},
+			wantMatched:   nil,
+			wantUnmatched: nil,
+		},
+		{
+			name:          "all_matched",
+			input:         []int{2, 4},
+			wantMatched:   []int{2, 4},
+			wantUnmatched: nil,
+		},
+		{
+			name:          "mixed",
+			input:         []int{1, 2, 3, 4, 5},
+			wantMatched:   []int{2, 4},
+			wantUnmatched: []int{1, 3, 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			matched, unmatched := Split(FromSlice(tc.input), func(i int) bool { return i%2 == 0 })
+			// consume the outputs one after the other, relying on the buffering
+			gotMatched := ToSlice(matched)
+			gotUnmatched := ToSlice(unmatched)
+			if diff := cmp.Diff(gotMatched, tc.wantMatched); diff != "" {
+				t.Errorf("unexpected matched result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(gotUnmatched, tc.wantUnmatched); diff != "" {
+				t.Errorf("unexpected unmatched result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package channel
+
+import (
+	"cmp"
+	"golang.org/x/exp/constraints"
+	"reflect"
+)
+
+// Number represents any real numeric type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Statistics holds the summary computed by Stats. Sum is accumulated in N, so
+// like any arithmetic on N it wraps around if it overflows a small integer
+// type. Mean is computed from a sum widened to int64, uint64 or float64, so it
+// does not.
+type Statistics[N Number] struct {
+	Count int64
+	Min   N
+	Max   N
+	Sum   N
+	Mean  float64
+}
+
+func Min[T constraints.Ordered](channel <-chan T) (T, bool) {
+	return MinBy(channel, cmp.Compare[T])
+}
+
+func Max[T constraints.Ordered](channel <-chan T) (T, bool) {
+	return MaxBy(channel, cmp.Compare[T])
+}
+
+func MinBy[T any](channel <-chan T, cmp func(a, b T) int) (T, bool) {
+	result, ok := <-channel
+	if !ok {
+		return result, false
+	}
+	for t := range channel {
+		if cmp(t, result) < 0 {
+			result = t
+		}
+	}
+	return result, true
+}
+
+func MaxBy[T any](channel <-chan T, cmp func(a, b T) int) (T, bool) {
+	return MinBy(channel, func(a, b T) int { return cmp(b, a) })
+}
+
+func Average[N Number](channel <-chan N) (float64, bool) {
+	stats := Stats(channel)
+	return stats.Mean, stats.Count > 0
+}
+
+// Stats consumes channel and computes its count, min, max, sum, and mean in a
+// single pass. All fields are zero for an empty channel.
+func Stats[N Number](channel <-chan N) Statistics[N] {
+	var stats Statistics[N]
+	// only one of the wide sums is used, chosen by the kind of N
+	var signed int64
+	var unsigned uint64
+	var float float64
+	kind := reflect.TypeFor[N]().Kind()
+	for n := range channel {
+		if stats.Count == 0 || n < stats.Min {
+			stats.Min = n
+		}
+		if stats.Count == 0 || n > stats.Max {
+			stats.Max = n
+		}
+		stats.Sum += n
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			signed += int64(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			unsigned += uint64(n)
+		default:
+			float += float64(n)
+		}
+		stats.Count++
+	}
+	if stats.Count > 0 {
+		stats.Mean = (float64(signed) + float64(unsigned) + float) / float64(stats.Count)
+	}
+	return stats
+}
@@ -0,0 +1,117 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		input   []int
+		wantMin int
+		wantMax int
+		wantOk  bool
+	}{
+		{
+			name:   "empty",
+			input:  []int{},
+			wantOk: false,
+		},
+		{
+			name:    "one",
+			input:   []int{1},
+			wantMin: 1,
+			wantMax: 1,
+			wantOk:  true,
+		},
+		{
+			name:    "many",
+			input:   []int{3, 1, 4, 1, 5, 9, 2, 6},
+			wantMin: 1,
+			wantMax: 9,
+			wantOk:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotMin, okMin := Min(FromSlice(tc.input))
+			gotMax, okMax := Max(FromSlice(tc.input))
+			if okMin != tc.wantOk || okMax != tc.wantOk {
+				t.Errorf("unexpected ok: got (%v, %v), want %v", okMin, okMax, tc.wantOk)
+			}
+			if diff := cmp.Diff(gotMin, tc.wantMin); diff != "" {
+				t.Errorf("unexpected min (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(gotMax, tc.wantMax); diff != "" {
+				t.Errorf("unexpected max (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  Statistics[int]
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			want:  Statistics[int]{},
+		},
+		{
+			name:  "one",
+			input: []int{-2},
+			want:  Statistics[int]{Count: 1, Min: -2, Max: -2, Sum: -2, Mean: -2},
+		},
+		{
+			name:  "many",
+			input: []int{3, 1, 4, 1, 5, 9, 2, 7},
+			want:  Statistics[int]{Count: 8, Min: 1, Max: 9, Sum: 32, Mean: 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Stats(FromSlice(tc.input))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestStatsSmallTypesDoNotOverflow(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff(Stats(Of[int8](100, 100, -80)), Statistics[int8]{Count: 3, Min: -80, Max: 100, Sum: 120, Mean: 40}); diff != "" {
+		t.Errorf("unexpected int8 result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Stats(Of[uint8](200, 100)), Statistics[uint8]{Count: 2, Min: 100, Max: 200, Sum: 44, Mean: 150}); diff != "" {
+		t.Errorf("unexpected uint8 result (-got, +want): %s", diff)
+	}
+	if got, ok := Average(Of[int8](100, 100)); !ok || got != 100 {
+		t.Errorf("unexpected average: got (%v, %v), want (100, true)", got, ok)
+	}
+}
+
+func TestStatsLargeSumIsExact(t *testing.T) {
+	t.Parallel()
+
+	// 2^53 + 1 cannot be represented as a float64
+	got := Stats(Of[int64](1<<53, 1))
+	if got.Sum != 1<<53+1 {
+		t.Errorf("unexpected sum: got %d, want %d", got.Sum, int64(1<<53+1))
+	}
+}
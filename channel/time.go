@@ -0,0 +1,133 @@
+package channel
+
+import (
+	"time"
+)
+
+// Delay shifts every value of channel by d, preserving the spacing between
+// values as they arrived.
+func Delay[T any](channel <-chan T, d time.Duration, opts ...Option) <-chan T {
+	type timed struct {
+		t   T
+		due time.Time
+	}
+	o := newOptions(opts)
+	delayed := makeChan[T](o)
+	go func() {
+		defer close(delayed)
+		timer := o.clock.NewTimer(d)
+		timer.Stop()
+		defer timer.Stop()
+		var queue []timed
+		in := channel
+		for in != nil || len(queue) > 0 {
+			var out chan T
+			var next T
+			var wait <-chan time.Time
+			if len(queue) > 0 {
+				if untilDue := queue[0].due.Sub(o.clock.Now()); untilDue <= 0 {
+					out, next = delayed, queue[0].t
+				} else {
+					timer.Reset(untilDue)
+					wait = timer.C()
+				}
+			}
+			select {
+			case t, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queue = append(queue, timed{t: t, due: o.clock.Now().Add(d)})
+			case out <- next:
+				queue = queue[1:]
+			case <-wait:
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return delayed
+}
+
+// Spread emits the values of channel no faster than one per interval. The
+// first value is emitted as soon as it is received.
+func Spread[T any](channel <-chan T, interval time.Duration, opts ...Option) <-chan T {
+	o := newOptions(opts)
+	spread := makeChan[T](o)
+	go func() {
+		defer close(spread)
+		var last time.Time
+		for t := range receiveAll(o.ctx, channel) {
+			if !last.IsZero() && !o.sleep(last.Add(interval).Sub(o.clock.Now())) {
+				return
+			}
+			if !send(o.ctx, spread, t) {
+				return
+			}
+			last = o.clock.Now()
+		}
+	}()
+	return spread
+}
+
+// sleep pauses for d on o.clock, reporting false if o.ctx is done first.
+func (o options) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return o.ctx.Err() == nil
+	}
+	timer := o.clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return true
+	case <-o.ctx.Done():
+		return false
+	}
+}
+
+// WithHeartbeat forwards the values of channel, along with a heartbeat
+// channel that pulses every interval for as long as the stage is running. A
+// supervisor that stops receiving pulses can assume the stage is wedged.
+// Pulses are dropped if the supervisor is not ready to receive them. Both
+// channels are closed once channel is closed.
+func WithHeartbeat[T any](channel <-chan T, interval time.Duration, opts ...Option) (<-chan T, <-chan struct{}) {
+	o := newOptions(opts)
+	out := makeChan[T](o)
+	heartbeat := make(chan struct{}, 1)
+	go func() {
+		defer close(heartbeat)
+		defer close(out)
+		ticker := o.clock.NewTicker(interval)
+		defer ticker.Stop()
+		pulse := func() {
+			select {
+			case heartbeat <- struct{}{}:
+			default:
+			}
+		}
+		for {
+			select {
+			case t, ok := <-channel:
+				if !ok {
+					return
+				}
+				for sent := false; !sent; {
+					select {
+					case out <- t:
+						sent = true
+					case <-ticker.C():
+						pulse()
+					case <-o.ctx.Done():
+						return
+					}
+				}
+			case <-ticker.C():
+				pulse()
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, heartbeat
+}
@@ -0,0 +1,100 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/clock"
+	"testing"
+	"time"
+)
+
+// newFakeClock returns a fake clock for tests of the time-based operators.
+func newFakeClock() *clock.Fake {
+	return clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestDelay(t *testing.T) {
+	t.Parallel()
+
+	const d = 20 * time.Millisecond
+	start := time.Now()
+	got := ToSlice(Delay(FromSlice([]int{1, 2, 3}), d))
+	if elapsed := time.Since(start); elapsed < d {
+		t.Errorf("expected values to be delayed by at least %v but took %v", d, elapsed)
+	}
+	if diff := cmp.Diff(got, []int{1, 2, 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestDelayWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	input := make(chan int)
+	delayed := Delay(input, time.Second, WithClock(f))
+	input <- 1
+	// the value is released once the clock has moved past its due time
+	f.BlockUntil(1)
+	f.Advance(time.Second)
+	if got := <-delayed; got != 1 {
+		t.Errorf("unexpected value: got %d, want 1", got)
+	}
+	close(input)
+	if got := ToSlice(delayed); len(got) != 0 {
+		t.Errorf("unexpected values after close: %v", got)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	t.Parallel()
+
+	const interval = 10 * time.Millisecond
+	start := time.Now()
+	got := ToSlice(Spread(FromSlice([]int{1, 2, 3}), interval))
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("expected values to be spread over at least %v but took %v", 2*interval, elapsed)
+	}
+	if diff := cmp.Diff(got, []int{1, 2, 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestSpreadWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	spread := Spread(FromSlice([]int{1, 2, 3}), time.Second, WithClock(f))
+	got := []int{<-spread}
+	for range 2 {
+		// the next value waits for the interval to pass
+		f.BlockUntil(1)
+		f.Advance(time.Second)
+		got = append(got, <-spread)
+	}
+	if diff := cmp.Diff(got, []int{1, 2, 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	Drain(spread)
+}
+
+func TestWithHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	input := make(chan int)
+	out, heartbeat := WithHeartbeat(input, time.Millisecond)
+	// the stage pulses while waiting on its upstream
+	<-heartbeat
+	go func() {
+		input <- 1
+		// and while waiting on its downstream
+		<-heartbeat
+		close(input)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	got := ToSlice(out)
+	if diff := cmp.Diff(got, []int{1}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	for range heartbeat {
+	}
+}
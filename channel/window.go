@@ -0,0 +1,241 @@
+package channel
+
+import (
+	"slices"
+	"time"
+)
+
+// Window emits sliding windows of size elements, starting a new window every
+// step elements. Trailing elements that do not fill a complete window are not
+// emitted. If size or step is not positive there are no windows, as with
+// slice.Windows: the returned channel is closed and channel is left unread.
+func Window[T any](channel <-chan T, size, step int, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	windows := makeChan[[]T](o)
+	if size <= 0 || step <= 0 {
+		close(windows)
+		return windows
+	}
+	go func() {
+		defer close(windows)
+		var window []T
+		skip := 0
+		for t := range receiveAll(o.ctx, channel) {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			window = append(window, t)
+			if len(window) == size {
+				if !send(o.ctx, windows, slices.Clone(window)) {
+					return
+				}
+				if step >= size {
+					skip = step - size
+					window = window[:0]
+				} else {
+					window = append(window[:0], window[step:]...)
+				}
+			}
+		}
+	}()
+	return windows
+}
+
+// WindowByTime emits sliding windows of the elements received during the last
+// size, starting a new window every slide. A window is emitted at the end of
+// every slide unless it is empty. When slide equals size the windows do not
+// overlap, and when it is greater than size the elements received between two
+// windows are not emitted. A slide less than or equal to zero is treated as
+// size. When channel is closed, a final window is emitted if it holds elements
+// that have not been emitted yet. If size is not positive there are no
+// windows: the returned channel is closed and channel is left unread.
+func WindowByTime[T any](channel <-chan T, size, slide time.Duration, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	windows := makeChan[[]T](o)
+	if size <= 0 {
+		close(windows)
+		return windows
+	}
+	if slide <= 0 {
+		slide = size
+	}
+	type timed struct {
+		t  T
+		at time.Time
+	}
+	go func() {
+		defer close(windows)
+		ticker := o.clock.NewTicker(slide)
+		defer ticker.Stop()
+		var buf []timed
+		// fresh counts the elements of buf that have not been emitted yet
+		fresh := 0
+		// evict drops the elements of buf received before cutoff
+		evict := func(cutoff time.Time, inclusive bool) {
+			i := 0
+			for i < len(buf) && (buf[i].at.Before(cutoff) || inclusive && buf[i].at.Equal(cutoff)) {
+				i++
+			}
+			buf = buf[i:]
+			fresh = min(fresh, len(buf))
+		}
+		window := func() []T {
+			w := make([]T, len(buf))
+			for i, e := range buf {
+				w[i] = e.t
+			}
+			return w
+		}
+		for {
+			select {
+			case t, ok := <-channel:
+				if !ok {
+					evict(o.clock.Now().Add(-size), false)
+					if fresh > 0 {
+						send(o.ctx, windows, window())
+					}
+					return
+				}
+				buf = append(buf, timed{t: t, at: o.clock.Now()})
+				fresh++
+			case now := <-ticker.C():
+				evict(now.Add(-size), false)
+				if len(buf) > 0 {
+					if !send(o.ctx, windows, window()) {
+						return
+					}
+					fresh = 0
+				}
+				// the next window ends a slide from now, so it only holds
+				// elements received after now-size+slide
+				evict(now.Add(slide-size), true)
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return windows
+}
+
+// SessionWindow groups the values of channel into sessions: a session ends
+// when the next value's timestamp is more than gap after the latest timestamp
+// of the session. A session also ends when no value has been received for gap,
+// so that a quiet channel does not hold back its last session.
+func SessionWindow[T any](channel <-chan T, timestamp func(T) time.Time, gap time.Duration, opts ...Option) <-chan []T {
+	o := newOptions(opts)
+	sessions := makeChan[[]T](o)
+	go func() {
+		defer close(sessions)
+		idle := o.clock.NewTimer(gap)
+		idle.Stop()
+		defer idle.Stop()
+		var session []T
+		var latest time.Time
+		for {
+			select {
+			case t, ok := <-channel:
+				if !ok {
+					if len(session) > 0 {
+						send(o.ctx, sessions, session)
+					}
+					return
+				}
+				ts := timestamp(t)
+				if len(session) > 0 && ts.Sub(latest) > gap {
+					if !send(o.ctx, sessions, session) {
+						return
+					}
+					session = nil
+				}
+				if len(session) == 0 || ts.After(latest) {
+					latest = ts
+				}
+				session = append(session, t)
+				idle.Reset(gap)
+			case <-idle.C():
+				if len(session) > 0 {
+					if !send(o.ctx, sessions, session) {
+						return
+					}
+					session = nil
+				}
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return sessions
+}
+
+// WindowSpec describes the windows used by WindowAggregate. If Duration is
+// positive, windows are formed as in WindowByTime using Duration and Slide.
+// Otherwise they are formed as in Window using Size and Step.
+type WindowSpec struct {
+	Size     int
+	Step     int
+	Duration time.Duration
+	Slide    time.Duration
+}
+
+// WindowAggregate applies agg to every window of channel described by spec.
+func WindowAggregate[T, R any](channel <-chan T, spec WindowSpec, agg func([]T) R, opts ...Option) <-chan R {
+	if spec.Duration > 0 {
+		return Map(WindowByTime(channel, spec.Duration, spec.Slide, opts...), agg, opts...)
+	}
+	return Map(Window(channel, spec.Size, spec.Step, opts...), agg, opts...)
+}
+
+// RollingAverage emits the average of the last window values for every value
+// of channel, once window values have been received.
+func RollingAverage[N Number](channel <-chan N, window int, opts ...Option) <-chan float64 {
+	return WindowAggregate(channel, WindowSpec{Size: window, Step: 1}, func(ns []N) float64 {
+		var sum float64
+		for _, n := range ns {
+			sum += float64(n)
+		}
+		return sum / float64(len(ns))
+	}, opts...)
+}
+
+// Rate emits the number of values received per second, measured over every
+// interval. When channel is closed, the rate over the final partial interval
+// is emitted if any values were received during it. If interval is not
+// positive no rate can be measured: the returned channel is closed and channel
+// is left unread.
+func Rate[T any](channel <-chan T, interval time.Duration, opts ...Option) <-chan float64 {
+	o := newOptions(opts)
+	rates := makeChan[float64](o)
+	if interval <= 0 {
+		close(rates)
+		return rates
+	}
+	go func() {
+		defer close(rates)
+		ticker := o.clock.NewTicker(interval)
+		defer ticker.Stop()
+		start := o.clock.Now()
+		count := 0
+		for {
+			select {
+			case _, ok := <-channel:
+				if !ok {
+					if elapsed := o.clock.Now().Sub(start); count > 0 && elapsed > 0 {
+						send(o.ctx, rates, float64(count)/elapsed.Seconds())
+					}
+					return
+				}
+				count++
+			case now := <-ticker.C():
+				if !send(o.ctx, rates, float64(count)/now.Sub(start).Seconds()) {
+					return
+				}
+				start = now
+				count = 0
+			case <-o.ctx.Done():
+				return
+			}
+		}
+	}()
+	return rates
+}
@@ -0,0 +1,306 @@
+package channel
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		size  int
+		step  int
+		want  [][]int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			size:  2,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "shorter_than_size",
+			input: []int{1, 2},
+			size:  3,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "sliding",
+			input: []int{1, 2, 3, 4, 5},
+			size:  3,
+			step:  1,
+			want:  [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		},
+		{
+			name:  "tumbling",
+			input: []int{1, 2, 3, 4, 5},
+			size:  2,
+			step:  2,
+			want:  [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:  "step_greater_than_size",
+			input: []int{1, 2, 3, 4, 5, 6, 7},
+			size:  2,
+			step:  3,
+			want:  [][]int{{1, 2}, {4, 5}},
+		},
+		{
+			name:  "zero_size",
+			input: []int{1, 2, 3},
+			size:  0,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "negative_size",
+			input: []int{1, 2, 3},
+			size:  -1,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "zero_step",
+			input: []int{1, 2, 3},
+			size:  2,
+			step:  0,
+			want:  nil,
+		},
+		{
+			name:  "negative_step",
+			input: []int{1, 2, 3},
+			size:  2,
+			step:  -1,
+			want:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := FromSlice(tc.input)
+			windows := Window(input, tc.size, tc.step)
+			got := ToSlice(windows)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			_, ok := <-windows
+			if ok {
+				t.Error("expected windows to be closed ")
+			}
+		})
+	}
+}
+
+func TestWindowByTime(t *testing.T) {
+	t.Parallel()
+
+	input := FromSlice([]int{1, 2, 3})
+	got := Flatten(Map(WindowByTime(input, time.Hour, time.Hour), FromSlice[int]))
+	if diff := cmp.Diff(ToSlice(got), []int{1, 2, 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestWindowByTimeNonPositiveSize(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []time.Duration{0, -time.Second} {
+		if got := ToSlice(WindowByTime(FromSlice([]int{1, 2, 3}), size, time.Second)); got != nil {
+			t.Errorf("unexpected windows for size %v: %v", size, got)
+		}
+	}
+}
+
+func TestWindowByTimeWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	input := make(chan int)
+	windows := WindowByTime(input, time.Second, time.Second, WithClock(f))
+	input <- 1
+	input <- 2
+	f.Advance(time.Second)
+	if diff := cmp.Diff(<-windows, []int{1, 2}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	// an interval without values produces no window
+	f.Advance(time.Second)
+	input <- 3
+	close(input)
+	if diff := cmp.Diff(ToSlice(windows), [][]int{{3}}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestWindowByTimeSliding(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	input := make(chan int)
+	windows := WindowByTime(input, 2*time.Second, time.Second, WithClock(f))
+	f.BlockUntil(1)
+	f.Advance(500 * time.Millisecond)
+	input <- 1
+	f.Advance(500 * time.Millisecond)
+	got := [][]int{<-windows}
+	f.Advance(500 * time.Millisecond)
+	input <- 2
+	f.Advance(500 * time.Millisecond)
+	got = append(got, <-windows)
+	f.Advance(time.Second)
+	got = append(got, <-windows)
+	// the window of the last two seconds is empty and produces no window
+	f.Advance(time.Second)
+	input <- 3
+	close(input)
+	got = append(got, ToSlice(windows)...)
+	if diff := cmp.Diff(got, [][]int{{1}, {1, 2}, {2}, {3}}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestSessionWindow(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		// input holds the event time of every value, in minutes after start
+		input []int
+		want  [][]int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			want:  nil,
+		},
+		{
+			name:  "one_session",
+			input: []int{0, 5, 10},
+			want:  [][]int{{0, 5, 10}},
+		},
+		{
+			name:  "gaps",
+			input: []int{0, 5, 20, 25, 40},
+			want:  [][]int{{0, 5}, {20, 25}, {40}},
+		},
+		{
+			name:  "gap_is_inclusive",
+			input: []int{0, 10, 21},
+			want:  [][]int{{0, 10}, {21}},
+		},
+		{
+			name:  "out_of_order",
+			input: []int{0, 8, 3, 16},
+			want:  [][]int{{0, 8, 3, 16}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			timestamp := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+			got := ToSlice(SessionWindow(FromSlice(tc.input), timestamp, 10*time.Minute))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSessionWindowIdle(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	input := make(chan int)
+	defer close(input)
+	sessions := SessionWindow(input, func(int) time.Time { return f.Now() }, time.Minute, WithClock(f))
+	input <- 1
+	// the session is emitted once the channel has been quiet for the gap
+	f.BlockUntil(1)
+	f.Advance(time.Minute)
+	if diff := cmp.Diff(<-sessions, []int{1}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		input  []int
+		window int
+		want   []float64
+	}{
+		{
+			name:   "empty",
+			input:  []int{},
+			window: 2,
+			want:   nil,
+		},
+		{
+			name:   "many",
+			input:  []int{1, 3, 5, 4, 8},
+			window: 2,
+			want:   []float64{2, 4, 4.5, 6},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ToSlice(RollingAverage(FromSlice(tc.input), tc.window))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	t.Parallel()
+
+	rates := ToSlice(Rate(FromSlice([]int{1, 2, 3}), time.Hour))
+	if len(rates) != 1 || rates[0] <= 0 {
+		t.Errorf("expected a single positive rate but got %v", rates)
+	}
+}
+
+func TestRateNonPositiveInterval(t *testing.T) {
+	t.Parallel()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if got := ToSlice(Rate(FromSlice([]int{1, 2, 3}), interval)); got != nil {
+			t.Errorf("unexpected rates for interval %v: %v", interval, got)
+		}
+	}
+}
+
+func TestRateWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	input := make(chan int)
+	rates := Rate(input, 2*time.Second, WithClock(f))
+	for i := range 3 {
+		input <- i
+	}
+	f.Advance(2 * time.Second)
+	if got := <-rates; got != 1.5 {
+		t.Errorf("unexpected rate: got %v, want 1.5", got)
+	}
+	close(input)
+	if got := ToSlice(rates); len(got) != 0 {
+		t.Errorf("unexpected rates after close: %v", got)
+	}
+}
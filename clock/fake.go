@@ -0,0 +1,176 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance is called, firing the
+// timers and tickers that become due along the way. It is safe for concurrent
+// use.
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a timer, ticker or AfterFunc of a Fake.
+type fakeWaiter struct {
+	fake   *Fake
+	due    time.Time
+	period time.Duration
+	c      chan time.Time
+	f      func()
+}
+
+// NewFake returns a Fake clock starting at now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{fake: f, c: make(chan time.Time, 1)}
+	w.reset(d, 0)
+	return w
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{fake: f, c: make(chan time.Time, 1)}
+	w.reset(d, d)
+	return fakeTicker{w}
+}
+
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	w := &fakeWaiter{fake: f, f: fn}
+	w.reset(d, 0)
+	return w
+}
+
+// Advance moves the time forward by d, firing every timer and ticker due by
+// then in order. Like their real counterparts, a fired timer or ticker drops
+// its value if the previous one has not been received, and AfterFunc runs its
+// function on its own goroutine.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	end := f.now.Add(d)
+	for {
+		next := -1
+		for i, w := range f.waiters {
+			if !w.due.After(end) && (next < 0 || w.due.Before(f.waiters[next].due)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		w := f.waiters[next]
+		f.now = w.due
+		if w.period > 0 {
+			w.due = w.due.Add(w.period)
+		} else {
+			f.remove(w)
+		}
+		if w.f != nil {
+			go w.f()
+		} else {
+			select {
+			case w.c <- f.now:
+			default:
+			}
+		}
+	}
+	f.now = end
+}
+
+// BlockUntil waits until n timers and tickers are pending, so that a test can
+// be sure an operator running on another goroutine has started waiting before
+// calling Advance.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.changed.Wait()
+	}
+}
+
+// remove must be called with f.mu held.
+func (f *Fake) remove(w *fakeWaiter) bool {
+	for i, other := range f.waiters {
+		if other == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			f.changed.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *fakeWaiter) Stop() bool {
+	w.fake.mu.Lock()
+	defer w.fake.mu.Unlock()
+	w.drain()
+	return w.fake.remove(w)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	return w.reset(d, 0)
+}
+
+// reset makes w fire after d, and then every period if it is positive.
+func (w *fakeWaiter) reset(d, period time.Duration) bool {
+	f := w.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.drain()
+	active := f.remove(w)
+	w.due = f.now.Add(d)
+	w.period = period
+	f.waiters = append(f.waiters, w)
+	f.changed.Broadcast()
+	return active
+}
+
+// drain discards a value fired but not yet received, so that it is not seen
+// after Stop or Reset.
+func (w *fakeWaiter) drain() {
+	select {
+	case <-w.c:
+	default:
+	}
+}
+
+type fakeTicker struct {
+	w *fakeWaiter
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.w.c
+}
+
+func (t fakeTicker) Stop() {
+	t.w.Stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.w.reset(d, d)
+}
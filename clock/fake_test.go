@@ -0,0 +1,104 @@
+package clock
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fired returns the value waiting in c, if any.
+func fired(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeTimer(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(epoch)
+	timer := f.NewTimer(time.Second)
+	f.Advance(999 * time.Millisecond)
+	if _, ok := fired(timer.C()); ok {
+		t.Fatalf("timer fired early")
+	}
+	f.Advance(time.Millisecond)
+	if got, ok := fired(timer.C()); !ok || !got.Equal(epoch.Add(time.Second)) {
+		t.Errorf("unexpected fire: got %v %v, want %v true", got, ok, epoch.Add(time.Second))
+	}
+	if timer.Stop() {
+		t.Errorf("Stop of a fired timer reported it active")
+	}
+	if timer.Reset(time.Second) {
+		t.Errorf("Reset of a fired timer reported it active")
+	}
+	if !timer.Stop() {
+		t.Errorf("Stop of a pending timer reported it inactive")
+	}
+	f.Advance(time.Hour)
+	if _, ok := fired(timer.C()); ok {
+		t.Errorf("stopped timer fired")
+	}
+}
+
+func TestFakeTimerResetDiscardsStaleValue(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(epoch)
+	timer := f.NewTimer(time.Second)
+	f.Advance(time.Second)
+	timer.Reset(time.Second)
+	if _, ok := fired(timer.C()); ok {
+		t.Errorf("received stale value after Reset")
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(epoch)
+	ticker := f.NewTicker(time.Second)
+	var got []time.Duration
+	for range 3 {
+		f.Advance(time.Second)
+		if tick, ok := fired(ticker.C()); ok {
+			got = append(got, tick.Sub(epoch))
+		}
+	}
+	// ticks not received in time are dropped
+	f.Advance(5 * time.Second)
+	if tick, ok := fired(ticker.C()); ok {
+		got = append(got, tick.Sub(epoch))
+	}
+	ticker.Stop()
+	f.Advance(time.Second)
+	if _, ok := fired(ticker.C()); ok {
+		t.Errorf("stopped ticker ticked")
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestFakeAfterFuncAndBlockUntil(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(epoch)
+	done := make(chan time.Time)
+	go f.AfterFunc(time.Minute, func() { done <- f.Now() })
+	f.BlockUntil(1)
+	f.Advance(2 * time.Minute)
+	// the function runs after Advance has moved the time to its end
+	if got, want := <-done, epoch.Add(2*time.Minute); !got.Equal(want) {
+		t.Errorf("unexpected time: got %v, want %v", got, want)
+	}
+	if got, want := f.Now(), epoch.Add(2*time.Minute); !got.Equal(want) {
+		t.Errorf("unexpected Now: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"iter"
+)
+
+// Collector describes a reduction of values of type T into a result of type
+// R. Every call starts a new reduction, returning a function that adds a value
+// to it and a function that returns its result.
+type Collector[T, R any] func() (add func(T), result func() R)
+
+// Of returns the collector that starts from supplier(), folds every value into
+// it with accumulate and produces its result with finish.
+func Of[T, A, R any](supplier func() A, accumulate func(A, T) A, finish func(A) R) Collector[T, R] {
+	return func() (func(T), func() R) {
+		a := supplier()
+		add := func(t T) {
+			a = accumulate(a, t)
+		}
+		result := func() R {
+			return finish(a)
+		}
+		return add, result
+	}
+}
+
+// Collect runs c over seq.
+func Collect[T, R any](seq iter.Seq[T], c Collector[T, R]) R {
+	add, result := c()
+	for t := range seq {
+		add(t)
+	}
+	return result()
+}
+
+// CollectSlice runs c over slice.
+func CollectSlice[T, R any](slice []T, c Collector[T, R]) R {
+	add, result := c()
+	for _, t := range slice {
+		add(t)
+	}
+	return result()
+}
+
+// CollectChan runs c over channel, returning once channel is closed.
+func CollectChan[T, R any](channel <-chan T, c Collector[T, R]) R {
+	add, result := c()
+	for t := range channel {
+		add(t)
+	}
+	return result()
+}
+
+func ToSlice[T any]() Collector[T, []T] {
+	return Of(func() []T { return nil }, func(ts []T, t T) []T { return append(ts, t) }, identity[[]T])
+}
+
+func Counting[T any]() Collector[T, int64] {
+	return Of(func() int64 { return 0 }, func(n int64, _ T) int64 { return n + 1 }, identity[int64])
+}
+
+// Reducing combines the values with op, starting from initial.
+func Reducing[T any](op func(T, T) T, initial T) Collector[T, T] {
+	return Of(func() T { return initial }, op, identity[T])
+}
+
+// Mapping applies f to every value before passing it to downstream.
+func Mapping[T, U, R any](f func(T) U, downstream Collector[U, R]) Collector[T, R] {
+	return func() (func(T), func() R) {
+		add, result := downstream()
+		return func(t T) { add(f(t)) }, result
+	}
+}
+
+// Filtering only passes the values for which p holds to downstream.
+func Filtering[T, R any](p func(T) bool, downstream Collector[T, R]) Collector[T, R] {
+	return func() (func(T), func() R) {
+		add, result := downstream()
+		return func(t T) {
+			if p(t) {
+				add(t)
+			}
+		}, result
+	}
+}
+
+// GroupingBy partitions the values by keyFn and collects the values of each
+// key with a separate reduction of downstream, in a single pass.
+func GroupingBy[T any, K comparable, R any](keyFn func(T) K, downstream Collector[T, R]) Collector[T, map[K]R] {
+	return func() (func(T), func() map[K]R) {
+		groups := make(map[K]func() R)
+		adders := make(map[K]func(T))
+		add := func(t T) {
+			key := keyFn(t)
+			addToGroup, ok := adders[key]
+			if !ok {
+				addToGroup, groups[key] = downstream()
+				adders[key] = addToGroup
+			}
+			addToGroup(t)
+		}
+		result := func() map[K]R {
+			m := make(map[K]R, len(groups))
+			for key, groupResult := range groups {
+				m[key] = groupResult()
+			}
+			return m
+		}
+		return add, result
+	}
+}
+
+func identity[T any](t T) T {
+	return t
+}
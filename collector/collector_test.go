@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/channel"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	firstLetter := func(s string) byte { return s[0] }
+
+	cases := []struct {
+		name string
+		// collect runs the collector under test over words
+		collect func([]string) any
+		want    any
+	}{
+		{
+			name:    "to_slice",
+			collect: func(ws []string) any { return CollectSlice(ws, ToSlice[string]()) },
+			want:    words,
+		},
+		{
+			name:    "counting",
+			collect: func(ws []string) any { return CollectSlice(ws, Counting[string]()) },
+			want:    int64(5),
+		},
+		{
+			name: "reducing",
+			collect: func(ws []string) any {
+				return CollectSlice(ws, Reducing(func(a, b string) string { return a + b[:1] }, ""))
+			},
+			want: "aabbc",
+		},
+		{
+			name:    "mapping",
+			collect: func(ws []string) any { return CollectSlice(ws, Mapping(strings.ToUpper, ToSlice[string]())) },
+			want:    []string{"APPLE", "AVOCADO", "BANANA", "BLUEBERRY", "CHERRY"},
+		},
+		{
+			name: "filtering",
+			collect: func(ws []string) any {
+				return CollectSlice(ws, Filtering(func(s string) bool { return len(s) > 6 }, ToSlice[string]()))
+			},
+			want: []string{"avocado", "blueberry"},
+		},
+		{
+			name:    "grouping_by_counting",
+			collect: func(ws []string) any { return CollectSlice(ws, GroupingBy(firstLetter, Counting[string]())) },
+			want:    map[byte]int64{'a': 2, 'b': 2, 'c': 1},
+		},
+		{
+			name:    "grouping_by_to_slice",
+			collect: func(ws []string) any { return CollectSlice(ws, GroupingBy(firstLetter, ToSlice[string]())) },
+			want:    map[byte][]string{'a': {"apple", "avocado"}, 'b': {"banana", "blueberry"}, 'c': {"cherry"}},
+		},
+		{
+			name: "grouping_by_reducing",
+			collect: func(ws []string) any {
+				return CollectSlice(ws, GroupingBy(firstLetter, Mapping(func(s string) int { return len(s) }, Reducing(func(a, b int) int { return max(a, b) }, 0))))
+			},
+			want: map[byte]int{'a': 7, 'b': 9, 'c': 6},
+		},
+		{
+			name:    "grouping_by_empty",
+			collect: func([]string) any { return CollectSlice(nil, GroupingBy(firstLetter, Counting[string]())) },
+			want:    map[byte]int64{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.collect(words), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectSources(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2, 3}
+	if diff := cmp.Diff(Collect(slices.Values(want), ToSlice[int]()), want); diff != "" {
+		t.Errorf("unexpected Collect result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(CollectChan(channel.FromSlice(want), ToSlice[int]()), want); diff != "" {
+		t.Errorf("unexpected CollectChan result (-got, +want): %s", diff)
+	}
+}
+
+func TestCollectorIsReusable(t *testing.T) {
+	t.Parallel()
+
+	counting := Counting[int]()
+	CollectSlice([]int{1, 2, 3}, counting)
+	if got := CollectSlice([]int{4}, counting); got != 1 {
+		t.Errorf("unexpected result: got %d, want 1", got)
+	}
+}
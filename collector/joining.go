@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JoiningOption configures Joining.
+type JoiningOption func(*joiningOptions)
+
+type joiningOptions struct {
+	prefix string
+	suffix string
+	limit  int
+	marker func(omitted int) string
+}
+
+// WithPrefix starts the joined string with prefix.
+func WithPrefix(prefix string) JoiningOption {
+	return func(o *joiningOptions) {
+		o.prefix = prefix
+	}
+}
+
+// WithSuffix ends the joined string with suffix.
+func WithSuffix(suffix string) JoiningOption {
+	return func(o *joiningOptions) {
+		o.suffix = suffix
+	}
+}
+
+// WithLimit joins at most n values. If there are more, the truncation marker
+// is joined in their place, see WithTruncationMarker. By default every value
+// is joined.
+func WithLimit(n int) JoiningOption {
+	return func(o *joiningOptions) {
+		o.limit = max(n, 0)
+	}
+}
+
+// WithTruncationMarker sets the function producing the marker that replaces
+// the values omitted due to WithLimit, given their number. The default marker
+// reads "… (+N more)".
+func WithTruncationMarker(marker func(omitted int) string) JoiningOption {
+	return func(o *joiningOptions) {
+		o.marker = marker
+	}
+}
+
+// Joining concatenates the values, separated by sep.
+func Joining(sep string, opts ...JoiningOption) Collector[string, string] {
+	o := joiningOptions{
+		limit:  -1,
+		marker: func(omitted int) string { return fmt.Sprintf("… (+%d more)", omitted) },
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func() (func(string), func() string) {
+		var b strings.Builder
+		joined, omitted := 0, 0
+		add := func(s string) {
+			if o.limit >= 0 && joined == o.limit {
+				omitted++
+				return
+			}
+			if joined > 0 {
+				b.WriteString(sep)
+			}
+			b.WriteString(s)
+			joined++
+		}
+		result := func() string {
+			parts := []string{o.prefix, b.String()}
+			if omitted > 0 {
+				if joined > 0 {
+					parts = append(parts, sep)
+				}
+				parts = append(parts, o.marker(omitted))
+			}
+			return strings.Join(append(parts, o.suffix), "")
+		}
+		return add, result
+	}
+}
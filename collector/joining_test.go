@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestJoining(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		sep   string
+		opts  []JoiningOption
+		want  string
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			sep:   ", ",
+			want:  "",
+		},
+		{
+			name:  "separator_only",
+			input: []string{"a", "b", "c"},
+			sep:   ", ",
+			want:  "a, b, c",
+		},
+		{
+			name:  "prefix_and_suffix",
+			input: []string{"a", "b", "c"},
+			sep:   ", ",
+			opts:  []JoiningOption{WithPrefix("["), WithSuffix("]")},
+			want:  "[a, b, c]",
+		},
+		{
+			name:  "prefix_and_suffix_empty",
+			input: nil,
+			sep:   ", ",
+			opts:  []JoiningOption{WithPrefix("["), WithSuffix("]")},
+			want:  "[]",
+		},
+		{
+			name:  "within_limit",
+			input: []string{"a", "b", "c"},
+			sep:   ", ",
+			opts:  []JoiningOption{WithLimit(3)},
+			want:  "a, b, c",
+		},
+		{
+			name:  "over_limit",
+			input: []string{"a", "b", "c", "d", "e"},
+			sep:   ", ",
+			opts:  []JoiningOption{WithLimit(2)},
+			want:  "a, b, … (+3 more)",
+		},
+		{
+			name:  "zero_limit",
+			input: []string{"a", "b"},
+			sep:   ", ",
+			opts:  []JoiningOption{WithLimit(0), WithPrefix("["), WithSuffix("]")},
+			want:  "[… (+2 more)]",
+		},
+		{
+			name:  "custom_marker",
+			input: []string{"a", "b", "c"},
+			sep:   "|",
+			opts: []JoiningOption{WithLimit(1), WithTruncationMarker(func(omitted int) string {
+				return fmt.Sprintf("%d hidden", omitted)
+			})},
+			want: "a|2 hidden",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := CollectSlice(tc.input, Joining(tc.sep, tc.opts...))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package funcs
+
+import "github.com/lock14/functional/tuple"
+
+// Compose2 returns the function that applies f and then g.
+func Compose2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C {
+		return g(f(a))
+	}
+}
+
+// Compose3 returns the function that applies f, then g, then h.
+func Compose3[A, B, C, D any](f func(A) B, g func(B) C, h func(C) D) func(A) D {
+	return Compose2(Compose2(f, g), h)
+}
+
+// Pipe returns the function that applies fs in order. Pipe with no functions
+// returns its input unchanged.
+func Pipe[T any](fs ...func(T) T) func(T) T {
+	return func(t T) T {
+		for _, f := range fs {
+			t = f(t)
+		}
+		return t
+	}
+}
+
+func Identity[T any](t T) T {
+	return t
+}
+
+// Constantly returns a function that ignores its argument and returns v.
+func Constantly[T, V any](v V) func(T) V {
+	return func(T) V {
+		return v
+	}
+}
+
+// Ignore is a consumer that does nothing with its argument.
+func Ignore[T any](T) {}
+
+// Tap returns a function that runs f for its side effect and then returns its
+// argument unchanged.
+func Tap[T any](f func(T)) func(T) T {
+	return func(t T) T {
+		f(t)
+		return t
+	}
+}
+
+// Tupled adapts a function of two arguments to take them as a pair, such as
+// those produced by slice.Zip and channel.Zip.
+func Tupled[A, B, C any](f func(A, B) C) func(tuple.Pair[A, B]) C {
+	return func(p tuple.Pair[A, B]) C {
+		return f(p.Fst, p.Snd)
+	}
+}
+
+// Untupled adapts a function of a pair to take its elements as two arguments.
+func Untupled[A, B, C any](f func(tuple.Pair[A, B]) C) func(A, B) C {
+	return func(a A, b B) C {
+		return f(tuple.Pair[A, B]{Fst: a, Snd: b})
+	}
+}
@@ -0,0 +1,91 @@
+package funcs
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/slice"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	double := func(i int) int { return i * 2 }
+	exclaim := func(s string) string { return s + "!" }
+
+	if diff := cmp.Diff(Compose2(double, strconv.Itoa)(21), "42"); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Compose3(double, strconv.Itoa, exclaim)(4), "8!"); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		fs    []func(string) string
+		input string
+		want  string
+	}{
+		{
+			name:  "pipe_none",
+			input: " a ",
+			want:  " a ",
+		},
+		{
+			name:  "pipe_many",
+			fs:    []func(string) string{strings.TrimSpace, strings.ToUpper, func(s string) string { return s + "b" }},
+			input: " a ",
+			want:  "Ab",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Pipe(tc.fs...)(tc.input)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestUtilities(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff(Identity("a"), "a"); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Constantly[int]("b")(1), "b"); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	Ignore(1)
+
+	var tapped []int
+	got := Tap(func(i int) { tapped = append(tapped, i) })(3)
+	if diff := cmp.Diff(got, 3); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(tapped, []int{3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestTupled(t *testing.T) {
+	t.Parallel()
+
+	repeat := Tupled(strings.Repeat)
+	got := slice.Map(slice.Zip([]string{"a", "b"}, []int{2, 3}), repeat)
+	if diff := cmp.Diff(got, []string{"aa", "bbb"}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Untupled(repeat)("c", 2), "cc"); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
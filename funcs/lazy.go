@@ -0,0 +1,34 @@
+package funcs
+
+import "sync"
+
+// Lazy is a value computed on first use. It is safe for concurrent use.
+type Lazy[T any] struct {
+	get func() T
+}
+
+// NewLazy returns a Lazy whose value is computed by f the first time Get is
+// called.
+func NewLazy[T any](f func() T) *Lazy[T] {
+	return &Lazy[T]{get: sync.OnceValue(f)}
+}
+
+// Get returns the value, computing it if this is the first call. Concurrent
+// first calls wait for a single computation.
+func (l *Lazy[T]) Get() T {
+	return l.get()
+}
+
+// LazyResult is like Lazy for a computation that can fail. The error is
+// computed once as well, so a failed computation is not retried.
+type LazyResult[T any] struct {
+	get func() (T, error)
+}
+
+func NewLazyResult[T any](f func() (T, error)) *LazyResult[T] {
+	return &LazyResult[T]{get: sync.OnceValues(f)}
+}
+
+func (l *LazyResult[T]) Get() (T, error) {
+	return l.get()
+}
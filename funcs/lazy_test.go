@@ -0,0 +1,56 @@
+package funcs
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	lazy := NewLazy(func() int {
+		calls.Add(1)
+		return 42
+	})
+	if diff := cmp.Diff(calls.Load(), int64(0)); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if diff := cmp.Diff(lazy.Get(), 42); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		}()
+	}
+	wg.Wait()
+	if diff := cmp.Diff(calls.Load(), int64(1)); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestLazyResult(t *testing.T) {
+	t.Parallel()
+
+	errInit := errors.New("init")
+	calls := 0
+	lazy := NewLazyResult(func() (string, error) {
+		calls++
+		return "", errInit
+	})
+	for range 2 {
+		if _, err := lazy.Get(); !errors.Is(err, errInit) {
+			t.Errorf("got error %v, want %v", err, errInit)
+		}
+	}
+	if diff := cmp.Diff(calls, 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,150 @@
+package funcs
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// WithTTL makes memoized results expire d after they were computed. By
+// default results never expire.
+func WithTTL(d time.Duration) Option {
+	return func(o *options) {
+		o.ttl = d
+	}
+}
+
+// WithMaxEntries bounds the number of memoized results, evicting the least
+// recently used one when the bound is exceeded. By default the number of
+// results is unbounded.
+func WithMaxEntries(n int) Option {
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// WithSingleFlight makes concurrent calls for the same key wait for a single
+// call of the memoized function instead of each calling it.
+func WithSingleFlight() Option {
+	return func(o *options) {
+		o.singleFlight = true
+	}
+}
+
+type memoEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+type memoCall[V any] struct {
+	done  chan struct{}
+	value V
+	ok    bool
+}
+
+type memo[K comparable, V any] struct {
+	f       func(K) V
+	o       options
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+	calls   map[K]*memoCall[V]
+}
+
+// Memoize returns a function that caches the results of f. The returned
+// function is safe for concurrent use.
+func Memoize[K comparable, V any](f func(K) V, opts ...Option) func(K) V {
+	o := newOptions(opts)
+	m := &memo[K, V]{
+		f:       f,
+		o:       o,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+		calls:   make(map[K]*memoCall[V]),
+	}
+	return m.get
+}
+
+func (m *memo[K, V]) get(k K) V {
+	for {
+		m.mu.Lock()
+		if v, ok := m.lookup(k); ok {
+			m.mu.Unlock()
+			return v
+		}
+		if !m.o.singleFlight {
+			m.mu.Unlock()
+			v := m.f(k)
+			m.mu.Lock()
+			m.store(k, v)
+			m.mu.Unlock()
+			return v
+		}
+		if c, ok := m.calls[k]; ok {
+			m.mu.Unlock()
+			<-c.done
+			if c.ok {
+				return c.value
+			}
+			// the call panicked, so try again
+			continue
+		}
+		c := &memoCall[V]{done: make(chan struct{})}
+		m.calls[k] = c
+		m.mu.Unlock()
+		return m.call(k, c)
+	}
+}
+
+func (m *memo[K, V]) call(k K, c *memoCall[V]) V {
+	defer func() {
+		m.mu.Lock()
+		delete(m.calls, k)
+		if c.ok {
+			m.store(k, c.value)
+		}
+		m.mu.Unlock()
+		close(c.done)
+	}()
+	c.value = m.f(k)
+	c.ok = true
+	return c.value
+}
+
+// lookup must be called with m.mu held.
+func (m *memo[K, V]) lookup(k K) (V, bool) {
+	elem, ok := m.entries[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := elem.Value.(*memoEntry[K, V])
+	if m.o.ttl > 0 && !m.o.clock.Now().Before(e.expires) {
+		m.lru.Remove(elem)
+		delete(m.entries, k)
+		var zero V
+		return zero, false
+	}
+	m.lru.MoveToFront(elem)
+	return e.value, true
+}
+
+// store must be called with m.mu held.
+func (m *memo[K, V]) store(k K, v V) {
+	e := &memoEntry[K, V]{key: k, value: v}
+	if m.o.ttl > 0 {
+		e.expires = m.o.clock.Now().Add(m.o.ttl)
+	}
+	if elem, ok := m.entries[k]; ok {
+		elem.Value = e
+		m.lru.MoveToFront(elem)
+		return
+	}
+	m.entries[k] = m.lru.PushFront(e)
+	if m.o.maxEntries > 0 && m.lru.Len() > m.o.maxEntries {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoEntry[K, V]).key)
+	}
+}
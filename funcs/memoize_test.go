@@ -0,0 +1,153 @@
+package funcs
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		opts      []Option
+		keys      []int
+		wantCalls []int
+	}{
+		{
+			name:      "memoize_unbounded",
+			keys:      []int{1, 2, 1, 3, 2, 1},
+			wantCalls: []int{1, 2, 3},
+		},
+		{
+			name:      "memoize_max_entries",
+			opts:      []Option{WithMaxEntries(2)},
+			keys:      []int{1, 2, 1, 3, 2, 1},
+			wantCalls: []int{1, 2, 3, 2, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []int
+			square := Memoize(func(i int) int {
+				calls = append(calls, i)
+				return i * i
+			}, tc.opts...)
+			for _, k := range tc.keys {
+				if diff := cmp.Diff(square(k), k*k); diff != "" {
+					t.Errorf("unexpected result (-got, +want): %s", diff)
+				}
+			}
+			if diff := cmp.Diff(calls, tc.wantCalls); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	f := Memoize(func(i int) int {
+		calls++
+		return i
+	}, WithTTL(20*time.Millisecond))
+	f(1)
+	f(1)
+	if diff := cmp.Diff(calls, 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	time.Sleep(30 * time.Millisecond)
+	f(1)
+	if diff := cmp.Diff(calls, 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestMemoizeTTLWithClock(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeClock()
+	var calls int
+	f := Memoize(func(i int) int {
+		calls++
+		return i
+	}, WithTTL(time.Minute), WithClock(c))
+	f(1)
+	c.Advance(59 * time.Second)
+	f(1)
+	if diff := cmp.Diff(calls, 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	c.Advance(time.Second)
+	f(1)
+	if diff := cmp.Diff(calls, 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestMemoizeSingleFlight(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	f := Memoize(func(i int) int {
+		calls.Add(1)
+		<-release
+		return i * 2
+	}, WithSingleFlight())
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = f(21)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if diff := cmp.Diff(calls.Load(), int64(1)); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	for _, got := range results {
+		if diff := cmp.Diff(got, 42); diff != "" {
+			t.Errorf("unexpected result (-got, +want): %s", diff)
+		}
+	}
+}
+
+func TestMemoizeSingleFlightPanic(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	f := Memoize(func(i int) int {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		return i
+	}, WithSingleFlight())
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected the first call to panic")
+			}
+		}()
+		f(1)
+	}()
+	if diff := cmp.Diff(f(1), 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,34 @@
+package funcs
+
+import (
+	"github.com/lock14/functional/clock"
+	"time"
+)
+
+// Option configures the functions of this package that accept options. Each
+// function ignores the options that do not apply to it.
+type Option func(*options)
+
+type options struct {
+	ttl          time.Duration
+	maxEntries   int
+	singleFlight bool
+	clock        clock.Clock
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: clock.Real}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithClock makes the time-based functions, such as Debounce, Retry and
+// Memoize with WithTTL, measure time with c instead of the real clock, so that
+// tests can advance time deterministically with a clock.Fake.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
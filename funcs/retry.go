@@ -0,0 +1,79 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how Retry calls a failing function again.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first one.
+	// Values below 1 mean a single call.
+	MaxAttempts int
+	// InitialDelay is the delay before the second call.
+	InitialDelay time.Duration
+	// Multiplier scales the delay after every failed retry. Values below 1
+	// mean a constant delay.
+	Multiplier float64
+	// MaxDelay caps the delay between calls. Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter randomly shortens every delay by up to this fraction of it, so
+	// that many callers failing together do not retry in lockstep. It is
+	// clamped to [0, 1].
+	Jitter float64
+	// RetryIf reports whether an error is worth retrying. Nil retries every
+	// error.
+	RetryIf func(error) bool
+}
+
+// ExponentialBackoff returns a policy making up to maxAttempts calls, starting
+// with a delay of initialDelay and doubling it after every failed retry.
+func ExponentialBackoff(maxAttempts int, initialDelay time.Duration) RetryPolicy {
+	return RetryPolicy{MaxAttempts: maxAttempts, InitialDelay: initialDelay, Multiplier: 2}
+}
+
+// Retry calls f until it succeeds, policy.RetryIf rejects its error, or
+// policy.MaxAttempts calls have been made, returning the result of the last
+// call. The delays between calls are measured with the clock set by
+// WithClock.
+func Retry[T any](f func() (T, error), policy RetryPolicy, opts ...Option) (T, error) {
+	return RetryCtx(context.Background(), f, policy, opts...)
+}
+
+// RetryCtx is like Retry, but stops waiting for the next call once ctx is
+// done, returning the error of the last call joined with ctx.Err().
+func RetryCtx[T any](ctx context.Context, f func() (T, error), policy RetryPolicy, opts ...Option) (T, error) {
+	o := newOptions(opts)
+	delay := policy.InitialDelay
+	for attempt := 1; ; attempt++ {
+		t, err := f()
+		if err == nil || attempt >= policy.MaxAttempts || (policy.RetryIf != nil && !policy.RetryIf(err)) {
+			return t, err
+		}
+		timer := o.clock.NewTimer(policy.jittered(delay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return t, errors.Join(err, ctx.Err())
+		case <-timer.C():
+		}
+		delay = policy.next(delay)
+	}
+}
+
+func (p RetryPolicy) next(delay time.Duration) time.Duration {
+	if p.Multiplier > 1 {
+		delay = time.Duration(float64(delay) * p.Multiplier)
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+func (p RetryPolicy) jittered(delay time.Duration) time.Duration {
+	jitter := min(max(p.Jitter, 0), 1)
+	return delay - time.Duration(jitter*rand.Float64()*float64(delay))
+}
@@ -0,0 +1,152 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+	"time"
+)
+
+var errRetry = errors.New("retry")
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	errFatal := errors.New("fatal")
+	cases := []struct {
+		name      string
+		failures  []error
+		policy    RetryPolicy
+		want      int
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "retry_succeeds_first",
+			policy:    ExponentialBackoff(3, time.Millisecond),
+			want:      1,
+			wantCalls: 1,
+		},
+		{
+			name:      "retry_succeeds_later",
+			failures:  []error{errRetry, errRetry},
+			policy:    ExponentialBackoff(3, time.Millisecond),
+			want:      3,
+			wantCalls: 3,
+		},
+		{
+			name:      "retry_exhausted",
+			failures:  []error{errRetry, errRetry, errRetry},
+			policy:    ExponentialBackoff(2, time.Millisecond),
+			wantErr:   errRetry,
+			wantCalls: 2,
+		},
+		{
+			name:      "retry_zero_attempts",
+			failures:  []error{errRetry},
+			policy:    RetryPolicy{},
+			wantErr:   errRetry,
+			wantCalls: 1,
+		},
+		{
+			name:     "retry_if_rejects",
+			failures: []error{errRetry, errFatal},
+			policy: RetryPolicy{
+				MaxAttempts:  5,
+				InitialDelay: time.Millisecond,
+				Jitter:       0.5,
+				RetryIf:      func(err error) bool { return !errors.Is(err, errFatal) },
+			},
+			wantErr:   errFatal,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			got, err := Retry(func() (int, error) {
+				calls++
+				if calls <= len(tc.failures) {
+					return 0, tc.failures[calls-1]
+				}
+				return calls, nil
+			}, tc.policy)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("got error %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(calls, tc.wantCalls); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRetryCtxCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := RetryCtx(ctx, func() (int, error) { return 0, errRetry }, ExponentialBackoff(3, time.Hour))
+	if !errors.Is(err, errRetry) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v and %v", err, errRetry, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RetryCtx took %v after its context was done", elapsed)
+	}
+}
+
+func TestRetryPolicyDelays(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{InitialDelay: time.Second, Multiplier: 2, MaxDelay: 5 * time.Second}
+	var got []time.Duration
+	for delay, i := policy.InitialDelay, 0; i < 4; delay, i = policy.next(delay), i+1 {
+		got = append(got, delay)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if d := policy.jittered(time.Second); d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("jittered delay %v out of range", d)
+		}
+	}
+}
+
+func TestRetryWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	start := f.Now()
+	var calls []time.Duration
+	done := make(chan error)
+	go func() {
+		_, err := Retry(func() (int, error) {
+			calls = append(calls, f.Now().Sub(start))
+			return 0, errRetry
+		}, ExponentialBackoff(3, time.Second), WithClock(f))
+		done <- err
+	}()
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second} {
+		f.BlockUntil(1)
+		f.Advance(delay)
+	}
+	if err := <-done; !errors.Is(err, errRetry) {
+		t.Errorf("got error %v, want %v", err, errRetry)
+	}
+	want := []time.Duration{0, time.Second, 3 * time.Second}
+	if diff := cmp.Diff(calls, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
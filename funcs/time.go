@@ -0,0 +1,62 @@
+package funcs
+
+import (
+	"github.com/lock14/functional/clock"
+	"sync"
+	"time"
+)
+
+// Debounce returns a function that delays calling f until d has passed
+// without another call, and then calls f with the argument of the last call.
+// f runs on its own goroutine. The returned function is safe for concurrent
+// use. d is measured with the clock set by WithClock.
+func Debounce[T any](f func(T), d time.Duration, opts ...Option) func(T) {
+	o := newOptions(opts)
+	var mu sync.Mutex
+	var timer clock.Timer
+	var last T
+	// generation identifies the latest call, so that a timer that fired
+	// before being stopped by a later call does not call f as well
+	var generation uint64
+	return func(t T) {
+		mu.Lock()
+		defer mu.Unlock()
+		last = t
+		generation++
+		g := generation
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = o.clock.AfterFunc(d, func() {
+			mu.Lock()
+			if g != generation {
+				mu.Unlock()
+				return
+			}
+			t := last
+			mu.Unlock()
+			f(t)
+		})
+	}
+}
+
+// Throttle returns a function that calls f at most once per interval. Calls
+// made less than interval after the last call that reached f are dropped. f
+// runs on the calling goroutine. The returned function is safe for concurrent
+// use. interval is measured with the clock set by WithClock.
+func Throttle[T any](f func(T), interval time.Duration, opts ...Option) func(T) {
+	o := newOptions(opts)
+	var mu sync.Mutex
+	var last time.Time
+	return func(t T) {
+		mu.Lock()
+		now := o.clock.Now()
+		if !last.IsZero() && now.Sub(last) < interval {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		f(t)
+	}
+}
@@ -0,0 +1,136 @@
+package funcs
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/clock"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var got []int
+	debounced := Debounce(func(i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, i)
+	}, 20*time.Millisecond)
+
+	for i := range 5 {
+		debounced(i)
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(60 * time.Millisecond)
+	debounced(10)
+	time.Sleep(60 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(got, []int{4, 10}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+	throttled := Throttle(func(i int) { got = append(got, i) }, 30*time.Millisecond)
+
+	throttled(1)
+	throttled(2)
+	throttled(3)
+	time.Sleep(40 * time.Millisecond)
+	throttled(4)
+	throttled(5)
+
+	if diff := cmp.Diff(got, []int{1, 4}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+// newFakeClock returns a fake clock for tests of the time-based functions.
+func newFakeClock() *clock.Fake {
+	return clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestDebounceWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	got := make(chan int)
+	debounced := Debounce(func(i int) { got <- i }, time.Second, WithClock(f))
+	for i := range 5 {
+		debounced(i)
+		f.Advance(999 * time.Millisecond)
+	}
+	f.Advance(time.Millisecond)
+	if v := <-got; v != 4 {
+		t.Errorf("unexpected value: got %d, want 4", v)
+	}
+}
+
+// gatedClock holds every function passed to AfterFunc until the test sends on
+// gate, and signals on done once the function has returned.
+type gatedClock struct {
+	clock.Clock
+	gate chan struct{}
+	done chan struct{}
+}
+
+func (c gatedClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	return c.Clock.AfterFunc(d, func() {
+		<-c.gate
+		f()
+		c.done <- struct{}{}
+	})
+}
+
+func TestDebounceStaleTimer(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	c := gatedClock{Clock: f, gate: make(chan struct{}), done: make(chan struct{})}
+	var mu sync.Mutex
+	var got []int
+	debounced := Debounce(func(i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, i)
+	}, time.Second, WithClock(c))
+
+	debounced(1)
+	// the first timer fires, but its function is held until after the
+	// second call, too late to be stopped
+	f.Advance(time.Second)
+	debounced(2)
+	c.gate <- struct{}{}
+	<-c.done
+	f.Advance(time.Second)
+	c.gate <- struct{}{}
+	<-c.done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(got, []int{2}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestThrottleWithClock(t *testing.T) {
+	t.Parallel()
+
+	f := newFakeClock()
+	var got []int
+	throttled := Throttle(func(i int) { got = append(got, i) }, time.Second, WithClock(f))
+	for i := range 5 {
+		throttled(i)
+		f.Advance(600 * time.Millisecond)
+	}
+	if diff := cmp.Diff(got, []int{0, 2, 4}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
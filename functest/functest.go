@@ -0,0 +1,115 @@
+// Package functest provides assertions for tests of code built on sequences
+// and channels.
+package functest
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"iter"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// AssertSeqEqual reports an error if seq does not yield exactly the values of
+// want, compared with cmp.Diff and opts.
+func AssertSeqEqual[T any](t testing.TB, seq iter.Seq[T], want []T, opts ...cmp.Option) {
+	t.Helper()
+	if diff := cmp.Diff(slices.Collect(seq), want, opts...); diff != "" {
+		t.Errorf("unexpected sequence (-got, +want): %s", diff)
+	}
+}
+
+// AssertChanEqual reports an error if channel does not receive exactly the
+// values of want, compared with cmp.Diff and opts, and then close, all within
+// timeout.
+func AssertChanEqual[T any](t testing.TB, channel <-chan T, want []T, timeout time.Duration, opts ...cmp.Option) {
+	t.Helper()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var got []T
+	for {
+		select {
+		case v, ok := <-channel:
+			if !ok {
+				if diff := cmp.Diff(got, want, opts...); diff != "" {
+					t.Errorf("unexpected channel values (-got, +want): %s", diff)
+				}
+				return
+			}
+			got = append(got, v)
+		case <-timer.C:
+			t.Errorf("channel not closed after %v, received %d values: %v", timeout, len(got), got)
+			return
+		}
+	}
+}
+
+// AssertClosed reports an error if channel receives a value or is still open
+// after timeout.
+func AssertClosed[T any](t testing.TB, channel <-chan T, timeout time.Duration) {
+	t.Helper()
+	select {
+	case v, ok := <-channel:
+		if ok {
+			t.Errorf("channel received %v, want it closed", v)
+		}
+	case <-time.After(timeout):
+		t.Errorf("channel not closed after %v", timeout)
+	}
+}
+
+// RequireNoGoroutineLeak fails the test if the number of running goroutines
+// does not return to what it was before the test within a second of its end.
+// Tests using it must not be run in parallel.
+func RequireNoGoroutineLeak(t testing.TB) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				buf := make([]byte, 1<<16)
+				buf = buf[:runtime.Stack(buf, true)]
+				t.Errorf("leaked %d goroutines:\n%s", runtime.NumGoroutine()-before, buf)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+// DiffErr describes how got differs from want, where a got error matches a
+// want error whose message it contains. It returns "" if they match.
+func DiffErr(got error, want error) string {
+	if got == nil && want == nil {
+		return ""
+	}
+	if got == nil {
+		return fmt.Sprintf("got error <nil> but want an error containing %q", want)
+	}
+	if want == nil {
+		return fmt.Sprintf("got error %q but want an error <nil>", got)
+	}
+	if gotMsg, wantMsg := got.Error(), want.Error(); !strings.Contains(gotMsg, wantMsg) {
+		out := fmt.Sprintf("got error %q but want an error containing %q", gotMsg, want)
+
+		// For long strings that will be hard to visually diff, include a diff.
+		// Explanation of the &&'s and ||'s: if we're diffing a long error
+		// message against a short one, a detailed diff isn't needed. The
+		// difference will be obvious to the eye, and any extra message will
+		// just be clutter. So only show the extra diff if the messages are both
+		// long, or both multi-line.
+		const msgLen = 20 // chosen arbitrarily
+		bothAreLong := len(wantMsg) >= msgLen && len(gotMsg) >= msgLen
+		bothAreMultiline := strings.Contains(wantMsg, "\n") && strings.Contains(gotMsg, "\n")
+		if bothAreLong || bothAreMultiline {
+			out += fmt.Sprintf("; diff was (-got,+want):\n%s", cmp.Diff(gotMsg, want))
+		}
+		return out
+	}
+	return ""
+}
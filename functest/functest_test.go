@@ -0,0 +1,122 @@
+package functest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recorder is a testing.TB that records the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func closedChan(values ...int) <-chan int {
+	ch := make(chan int, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		// assert runs the assertion under test against t
+		assert   func(t testing.TB)
+		wantFail bool
+	}{
+		{
+			name:   "seq_equal",
+			assert: func(t testing.TB) { AssertSeqEqual(t, slices.Values([]int{1, 2}), []int{1, 2}) },
+		},
+		{
+			name:     "seq_not_equal",
+			assert:   func(t testing.TB) { AssertSeqEqual(t, slices.Values([]int{1, 2}), []int{1, 3}) },
+			wantFail: true,
+		},
+		{
+			name:   "chan_equal",
+			assert: func(t testing.TB) { AssertChanEqual(t, closedChan(1, 2), []int{1, 2}, time.Second) },
+		},
+		{
+			name:     "chan_not_equal",
+			assert:   func(t testing.TB) { AssertChanEqual(t, closedChan(1), []int{1, 2}, time.Second) },
+			wantFail: true,
+		},
+		{
+			name:     "chan_not_closed",
+			assert:   func(t testing.TB) { AssertChanEqual(t, make(chan int), nil, time.Millisecond) },
+			wantFail: true,
+		},
+		{
+			name:   "closed",
+			assert: func(t testing.TB) { AssertClosed(t, closedChan(), time.Second) },
+		},
+		{
+			name:     "closed_with_value",
+			assert:   func(t testing.TB) { AssertClosed(t, closedChan(1), time.Second) },
+			wantFail: true,
+		},
+		{
+			name:     "not_closed",
+			assert:   func(t testing.TB) { AssertClosed(t, make(chan int), time.Millisecond) },
+			wantFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := &recorder{TB: t}
+			tc.assert(r)
+			if gotFail := len(r.errors) > 0; gotFail != tc.wantFail {
+				t.Errorf("unexpected failure: got %v, want %v, errors: %q", gotFail, tc.wantFail, r.errors)
+			}
+		})
+	}
+}
+
+func TestDiffErr(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		got, want error
+		wantDiff  bool
+	}{
+		{name: "both_nil"},
+		{name: "contains", got: errors.New("read: connection reset"), want: errors.New("connection reset")},
+		{name: "unexpected_error", got: errors.New("boom"), wantDiff: true},
+		{name: "missing_error", want: errors.New("boom"), wantDiff: true},
+		{name: "different_error", got: errors.New("boom"), want: errors.New("bang"), wantDiff: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := DiffErr(tc.got, tc.want); (diff != "") != tc.wantDiff {
+				t.Errorf("unexpected diff %q, want a diff: %v", diff, tc.wantDiff)
+			}
+		})
+	}
+}
+
+func TestRequireNoGoroutineLeak(t *testing.T) {
+	RequireNoGoroutineLeak(t)
+	done := make(chan struct{})
+	go func() { close(done) }()
+	<-done
+}
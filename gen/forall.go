@@ -0,0 +1,64 @@
+package gen
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// Option configures ForAll.
+type Option func(*options)
+
+type options struct {
+	runs    int
+	maxSize int
+	seed    uint64
+	seeded  bool
+}
+
+// WithRuns checks the property against n values. The default is 100.
+func WithRuns(n int) Option {
+	return func(o *options) {
+		o.runs = n
+	}
+}
+
+// WithMaxSize sets the size of the last value checked, see Gen. Sizes grow
+// linearly from 0 over the runs. The default is 100.
+func WithMaxSize(size int) Option {
+	return func(o *options) {
+		o.maxSize = size
+	}
+}
+
+// WithSeed seeds the random values, to reproduce a failure reported by
+// ForAll. By default a random seed is used.
+func WithSeed(seed uint64) Option {
+	return func(o *options) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// ForAll checks that prop holds for values produced by g, reporting the first
+// value for which it does not along with the seed that reproduces it.
+func ForAll[T any](t testing.TB, g Gen[T], prop func(T) bool, opts ...Option) {
+	t.Helper()
+	o := options{runs: 100, maxSize: 100}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.seeded {
+		o.seed = rand.Uint64()
+	}
+	r := rand.New(rand.NewPCG(o.seed, o.seed))
+	for run := range o.runs {
+		size := 0
+		if o.runs > 1 {
+			size = run * o.maxSize / (o.runs - 1)
+		}
+		if v := g(r, size); !prop(v) {
+			t.Errorf("property does not hold for %v (run %d, size %d, reproduce with gen.WithSeed(%d))", v, run, size, o.seed)
+			return
+		}
+	}
+}
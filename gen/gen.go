@@ -0,0 +1,158 @@
+// Package gen generates random values, including sequences, channels and
+// slices, for property-based tests run with ForAll.
+package gen
+
+import (
+	"golang.org/x/exp/constraints"
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// Gen produces a random value from r. Generators of variable sized values,
+// such as slices and strings, keep their length within size.
+type Gen[T any] func(r *rand.Rand, size int) T
+
+// Const always produces t.
+func Const[T any](t T) Gen[T] {
+	return func(*rand.Rand, int) T {
+		return t
+	}
+}
+
+// Int produces integers uniformly distributed in [lo, hi].
+func Int[T constraints.Integer](lo, hi T) Gen[T] {
+	return func(r *rand.Rand, _ int) T {
+		return lo + T(r.Uint64N(uint64(hi-lo)+1))
+	}
+}
+
+// Float produces floats uniformly distributed in [lo, hi).
+func Float(lo, hi float64) Gen[float64] {
+	return func(r *rand.Rand, _ int) float64 {
+		return lo + r.Float64()*(hi-lo)
+	}
+}
+
+// Bool produces true with probability p.
+func Bool(p float64) Gen[bool] {
+	return func(r *rand.Rand, _ int) bool {
+		return r.Float64() < p
+	}
+}
+
+// Elements produces one of values, each equally likely.
+func Elements[T any](values ...T) Gen[T] {
+	return func(r *rand.Rand, _ int) T {
+		return values[r.IntN(len(values))]
+	}
+}
+
+// OneOf produces the value of one of gens, each equally likely.
+func OneOf[T any](gens ...Gen[T]) Gen[T] {
+	return func(r *rand.Rand, size int) T {
+		return gens[r.IntN(len(gens))](r, size)
+	}
+}
+
+// Choice is a generator chosen by Frequency in proportion to its Weight.
+type Choice[T any] struct {
+	Weight int
+	Gen    Gen[T]
+}
+
+// Frequency produces the value of one of choices, each chosen in proportion
+// to its weight.
+func Frequency[T any](choices ...Choice[T]) Gen[T] {
+	total := 0
+	for _, c := range choices {
+		total += c.Weight
+	}
+	return func(r *rand.Rand, size int) T {
+		n := r.IntN(total)
+		for _, c := range choices[:len(choices)-1] {
+			if n < c.Weight {
+				return c.Gen(r, size)
+			}
+			n -= c.Weight
+		}
+		return choices[len(choices)-1].Gen(r, size)
+	}
+}
+
+// String produces strings of up to size runes drawn from alphabet.
+func String(alphabet string) Gen[string] {
+	runes := SliceOf(Elements([]rune(alphabet)...))
+	return func(r *rand.Rand, size int) string {
+		return string(runes(r, size))
+	}
+}
+
+// Map produces f of the values of g. Together with Map2 it builds generators
+// of structs from generators of their fields.
+func Map[T, U any](g Gen[T], f func(T) U) Gen[U] {
+	return func(r *rand.Rand, size int) U {
+		return f(g(r, size))
+	}
+}
+
+// Map2 produces f of the values of g1 and g2.
+func Map2[T1, T2, U any](g1 Gen[T1], g2 Gen[T2], f func(T1, T2) U) Gen[U] {
+	return func(r *rand.Rand, size int) U {
+		return f(g1(r, size), g2(r, size))
+	}
+}
+
+// Filter produces the values of g for which p holds, retrying until one does.
+func Filter[T any](g Gen[T], p func(T) bool) Gen[T] {
+	return func(r *rand.Rand, size int) T {
+		for {
+			if t := g(r, size); p(t) {
+				return t
+			}
+		}
+	}
+}
+
+// Resize produces the values of g with size fixed to size.
+func Resize[T any](g Gen[T], size int) Gen[T] {
+	return func(r *rand.Rand, _ int) T {
+		return g(r, size)
+	}
+}
+
+// SliceOf produces slices of up to size values of g.
+func SliceOf[T any](g Gen[T]) Gen[[]T] {
+	return func(r *rand.Rand, size int) []T {
+		return SliceOfN(g, 0, size)(r, size)
+	}
+}
+
+// SliceOfN produces slices of between minLen and maxLen values of g.
+func SliceOfN[T any](g Gen[T], minLen, maxLen int) Gen[[]T] {
+	return func(r *rand.Rand, size int) []T {
+		ts := make([]T, minLen+r.IntN(maxLen-minLen+1))
+		for i := range ts {
+			ts[i] = g(r, size)
+		}
+		return ts
+	}
+}
+
+// SeqOf produces sequences of up to size values of g. Every produced sequence
+// yields the same values each time it is iterated.
+func SeqOf[T any](g Gen[T]) Gen[iter.Seq[T]] {
+	return Map(SliceOf(g), slices.Values[[]T])
+}
+
+// ChanOf produces closed channels buffering up to size values of g.
+func ChanOf[T any](g Gen[T]) Gen[<-chan T] {
+	return Map(SliceOf(g), func(ts []T) <-chan T {
+		ch := make(chan T, len(ts))
+		for _, t := range ts {
+			ch <- t
+		}
+		close(ch)
+		return ch
+	})
+}
@@ -0,0 +1,165 @@
+package gen
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/channel"
+	"github.com/lock14/functional/iterator"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// recorder is a testing.TB that records the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type point struct {
+	X, Y int
+}
+
+func TestGenerators(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		// holds checks a property of the values of the generator under test
+		holds func(t testing.TB)
+	}{
+		{
+			name: "int_in_range",
+			holds: func(t testing.TB) {
+				ForAll(t, Int(-3, 3), func(i int) bool { return -3 <= i && i <= 3 })
+			},
+		},
+		{
+			name: "float_in_range",
+			holds: func(t testing.TB) {
+				ForAll(t, Float(1, 2), func(f float64) bool { return 1 <= f && f < 2 })
+			},
+		},
+		{
+			name: "string_from_alphabet_within_size",
+			holds: func(t testing.TB) {
+				ForAll(t, String("aβc"), func(s string) bool {
+					return utf8.RuneCountInString(s) <= 100 && strings.Trim(s, "aβc") == ""
+				})
+			},
+		},
+		{
+			name: "frequency_skips_zero_weight",
+			holds: func(t testing.TB) {
+				g := Frequency(Choice[int]{Weight: 1, Gen: Const(1)}, Choice[int]{Weight: 0, Gen: Const(2)}, Choice[int]{Weight: 3, Gen: Const(3)})
+				ForAll(t, g, func(i int) bool { return i != 2 })
+			},
+		},
+		{
+			name: "struct",
+			holds: func(t testing.TB) {
+				g := Map2(Int(0, 9), Int(10, 19), func(x, y int) point { return point{X: x, Y: y} })
+				ForAll(t, g, func(p point) bool { return p.X < 10 && p.Y >= 10 })
+			},
+		},
+		{
+			name: "filter",
+			holds: func(t testing.TB) {
+				ForAll(t, Filter(Int(0, 100), func(i int) bool { return i%2 == 0 }), func(i int) bool { return i%2 == 0 })
+			},
+		},
+		{
+			name: "slice_of_n_length",
+			holds: func(t testing.TB) {
+				ForAll(t, SliceOfN(Bool(0.5), 2, 4), func(bs []bool) bool { return 2 <= len(bs) && len(bs) <= 4 })
+			},
+		},
+		{
+			name: "resize",
+			holds: func(t testing.TB) {
+				ForAll(t, Resize(SliceOf(Int(0, 1)), 3), func(is []int) bool { return len(is) <= 3 })
+			},
+		},
+		{
+			name: "seq_map_preserves_length",
+			holds: func(t testing.TB) {
+				ForAll(t, SeqOf(Int(0, 1000)), func(seq iter.Seq[int]) bool {
+					return iterator.Count(iterator.Map(seq, func(i int) string { return fmt.Sprint(i) })) == iterator.Count(seq)
+				})
+			},
+		},
+		{
+			name: "chan_map_preserves_values",
+			holds: func(t testing.TB) {
+				ForAll(t, SliceOf(Int(0, 1000)), func(is []int) bool {
+					got := channel.ToSlice(channel.Map(channel.FromSlice(is), func(i int) int { return i }))
+					return slices.Equal(got, is)
+				})
+			},
+		},
+		{
+			name: "chan_closed",
+			holds: func(t testing.TB) {
+				ForAll(t, ChanOf(Elements("a", "b")), func(ch <-chan string) bool {
+					return len(channel.ToSlice(ch)) <= 100
+				})
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.holds(t)
+		})
+	}
+}
+
+func TestForAllReportsFailure(t *testing.T) {
+	t.Parallel()
+
+	r := &recorder{TB: t}
+	ForAll(r, Int(0, 100), func(i int) bool { return i < 50 }, WithSeed(7))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "gen.WithSeed(7)") {
+		t.Errorf("unexpected errors: %q", r.errors)
+	}
+}
+
+func TestForAllSizes(t *testing.T) {
+	t.Parallel()
+
+	var sizes []int
+	sized := func(_ *rand.Rand, size int) int { return size }
+	ForAll(t, sized, func(size int) bool {
+		sizes = append(sizes, size)
+		return true
+	}, WithRuns(5), WithMaxSize(8))
+	if diff := cmp.Diff(sizes, []int{0, 2, 4, 6, 8}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestWithSeedIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	collect := func() [][]int {
+		var got [][]int
+		ForAll(t, SliceOf(Int(0, 1000)), func(is []int) bool {
+			got = append(got, is)
+			return true
+		}, WithSeed(42), WithRuns(10))
+		return got
+	}
+	if diff := cmp.Diff(collect(), collect()); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,33 @@
+package iterator
+
+import (
+	"iter"
+	"testing"
+)
+
+func BenchmarkMap(b *testing.B) {
+	for range Map(Range(0, b.N), func(i int) int { return i * 2 }) {
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	for range Filter(Range(0, b.N), func(i int) bool { return i%2 == 0 }) {
+	}
+}
+
+func BenchmarkFlatMap(b *testing.B) {
+	for range FlatMap(Range(0, b.N/4+1), func(i int) iter.Seq[int] { return Range(i, i+4) }) {
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	Reduce(Range(0, b.N), func(a, b int) int { return a + b }, 0)
+}
+
+func BenchmarkSum(b *testing.B) {
+	Sum(Range(0, b.N))
+}
+
+func BenchmarkCount(b *testing.B) {
+	Count(Range(0, b.N))
+}
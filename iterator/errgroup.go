@@ -0,0 +1,31 @@
+package iterator
+
+import (
+	"golang.org/x/sync/errgroup"
+	"iter"
+	"sync/atomic"
+)
+
+// GoEach schedules f on g for every value of itr, so the number of values
+// handled at once is bounded by the limit of g, see errgroup.Group.SetLimit.
+// It returns once itr is exhausted or a task has failed, after which f is not
+// called again and no more values are pulled from itr; g.Wait returns the
+// first error.
+func GoEach[T any](g *errgroup.Group, itr iter.Seq[T], f func(T) error) {
+	var failed atomic.Bool
+	for t := range itr {
+		if failed.Load() {
+			return
+		}
+		g.Go(func() error {
+			if failed.Load() {
+				return nil
+			}
+			if err := f(t); err != nil {
+				failed.Store(true)
+				return err
+			}
+			return nil
+		})
+	}
+}
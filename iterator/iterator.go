@@ -6,6 +6,7 @@ import (
 	"github.com/lock14/functional/slice"
 	"golang.org/x/exp/constraints"
 	"iter"
+	"math/rand/v2"
 	"slices"
 )
 
@@ -54,6 +55,20 @@ func Filter[T any](itr iter.Seq[T], p func(T) bool) iter.Seq[T] {
 	}
 }
 
+// FilterMap transforms and filters itr in a single pass, yielding f(t) for
+// every t for which f reports true.
+func FilterMap[T, U any](itr iter.Seq[T], f func(T) (U, bool)) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for t := range itr {
+			if u, ok := f(t); ok {
+				if !yield(u) {
+					break
+				}
+			}
+		}
+	}
+}
+
 func FoldLeft[T, U any](itr iter.Seq[T], f func(U, T) U, u U) U {
 	result := u
 	for t := range itr {
@@ -81,8 +96,11 @@ func Reduce[T any](itr iter.Seq[T], f func(T, T) T, t T) T {
 }
 
 func Sum[M Monad](itr iter.Seq[M]) M {
-	var identity M
-	return Reduce(itr, func(a, b M) M { return a + b }, identity)
+	var sum M
+	for m := range itr {
+		sum += m
+	}
+	return sum
 }
 
 func JoinErrs(itr iter.Seq[error]) error {
@@ -103,6 +121,69 @@ func Join[T ~string](itr iter.Seq[T], sep T) T {
 	return result
 }
 
+// ToSliceInto appends the values of itr to buf and returns the extended slice.
+// Passing buf[:0] reuses its capacity across calls.
+func ToSliceInto[T any](itr iter.Seq[T], buf []T) []T {
+	return slices.AppendSeq(buf, itr)
+}
+
+// CollectInto adds the pairs of seq to m, which is allocated if nil, and
+// returns it. When a key is already present, resolve is called with the key,
+// the value already in the map, and the incoming value to determine the value
+// to keep. A nil resolve keeps the last value.
+func CollectInto[K comparable, V any](seq iter.Seq2[K, V], m map[K]V, resolve func(k K, existing, incoming V) V) map[K]V {
+	if m == nil {
+		m = make(map[K]V)
+	}
+	for k, v := range seq {
+		if existing, ok := m[k]; ok && resolve != nil {
+			m[k] = resolve(k, existing, v)
+		} else {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// ReduceByKey combines the values of seq that share a key using reduce, in the
+// order they occur.
+func ReduceByKey[K comparable, V any](seq iter.Seq2[K, V], reduce func(V, V) V) map[K]V {
+	m := make(map[K]V)
+	for k, v := range seq {
+		if existing, ok := m[k]; ok {
+			m[k] = reduce(existing, v)
+		} else {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// AggregateByKey folds the values of every key of each partition into an
+// aggregate starting from seed(), then merges the aggregates of a key across
+// partitions with combine. Only the aggregates are held in memory.
+func AggregateByKey[K comparable, V, A any](seed func() A, accumulate func(A, V) A, combine func(A, A) A, partitions ...iter.Seq2[K, V]) map[K]A {
+	result := make(map[K]A)
+	for _, partition := range partitions {
+		aggregates := make(map[K]A)
+		for k, v := range partition {
+			a, ok := aggregates[k]
+			if !ok {
+				a = seed()
+			}
+			aggregates[k] = accumulate(a, v)
+		}
+		for k, a := range aggregates {
+			if existing, ok := result[k]; ok {
+				result[k] = combine(existing, a)
+			} else {
+				result[k] = a
+			}
+		}
+	}
+	return result
+}
+
 func Zip[T, U any](itr1 iter.Seq[T], itr2 iter.Seq[U]) iter.Seq2[T, U] {
 	return func(yield func(T, U) bool) {
 		next1, stop1 := iter.Pull(itr1)
@@ -202,7 +283,27 @@ func AnyMatch[T any](itr iter.Seq[T], p func(T) bool) bool {
 }
 
 func Count[T any](itr iter.Seq[T]) int64 {
-	return Sum(Map(itr, func(t T) int64 { return 1 }))
+	var count int64
+	for range itr {
+		count++
+	}
+	return count
+}
+
+// CountAtMost is like Count but stops consuming itr once max values have been
+// counted, so it can be used on expensive or infinite sequences.
+func CountAtMost[T any](itr iter.Seq[T], max int64) int64 {
+	var count int64
+	if max <= 0 {
+		return count
+	}
+	for range itr {
+		count++
+		if count == max {
+			break
+		}
+	}
+	return count
 }
 
 func Concat[T any](itrs ...iter.Seq[T]) iter.Seq[T] {
@@ -220,6 +321,80 @@ func Peek[T any](itr iter.Seq[T], consumer func(T)) iter.Seq[T] {
 	}
 }
 
+// ForEachIndexed calls consumer for every value of itr along with its
+// position, starting at 0.
+func ForEachIndexed[T any](itr iter.Seq[T], consumer func(int, T)) {
+	i := 0
+	for t := range itr {
+		consumer(i, t)
+		i++
+	}
+}
+
+// ForEachWhile calls consumer for every value of itr until consumer returns
+// false.
+func ForEachWhile[T any](itr iter.Seq[T], consumer func(T) bool) {
+	for t := range itr {
+		if !consumer(t) {
+			break
+		}
+	}
+}
+
+// PeekEvery is like Peek but only calls consumer for every nth value, starting
+// with the first. An n less than 1 is treated as 1.
+func PeekEvery[T any](itr iter.Seq[T], n int, consumer func(T)) iter.Seq[T] {
+	n = max(n, 1)
+	return func(yield func(T) bool) {
+		count := 0
+		Peek(itr, func(t T) {
+			if count%n == 0 {
+				consumer(t)
+			}
+			count++
+		})(yield)
+	}
+}
+
+// PeekSampled is like Peek but only calls consumer for a random sample of the
+// values, each value being sampled with probability rate.
+func PeekSampled[T any](itr iter.Seq[T], rate float64, consumer func(T)) iter.Seq[T] {
+	return Peek(itr, func(t T) {
+		if rand.Float64() < rate {
+			consumer(t)
+		}
+	})
+}
+
+// Paginate lazily walks a paginated source, such as a cursor based API. fetch
+// is first called with the zero cursor and then with the cursor it returned,
+// until it reports done or fails. The items of every page are yielded with a
+// nil error. A failure is yielded after the items of its page, with the zero
+// value of T, and ends the sequence. Pages are only fetched as they are
+// needed, so stopping the iteration early skips the remaining pages.
+func Paginate[T, C any](fetch func(cursor C) (items []T, next C, done bool, err error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var cursor C
+		for {
+			items, next, done, err := fetch(cursor)
+			for _, t := range items {
+				if !yield(t, nil) {
+					return
+				}
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if done {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
 func Of[T any](ts ...T) iter.Seq[T] {
 	return slices.Values(ts)
 }
@@ -1,14 +1,15 @@
 package iterator
 
 import (
+	"errors"
 	"fmt"
 	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/functest"
 	"github.com/lock14/functional/slice"
 	"iter"
 	"maps"
 	"slices"
 	"strconv"
-	"strings"
 	"testing"
 )
 
@@ -178,6 +179,42 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterMap(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	}
+	cases := []struct {
+		name  string
+		input []string
+		want  []int
+	}{
+		{
+			name:  "filter_map_empty",
+			input: []string{},
+			want:  nil,
+		},
+		{
+			name:  "filter_map_many",
+			input: []string{"1", "x", "3", "", "5"},
+			want:  []int{1, 3, 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := slices.Collect(FilterMap(slices.Values(tc.input), parse))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
 func TestFoldLeft(t *testing.T) {
 	t.Parallel()
 
@@ -406,7 +443,7 @@ func TestJoinErrs(t *testing.T) {
 
 			input := slices.Values(tc.input)
 			got := JoinErrs(input)
-			if diff := DiffErr(got, tc.want); diff != "" {
+			if diff := functest.DiffErr(got, tc.want); diff != "" {
 				t.Errorf("unexpected result (-got, +want): %s", diff)
 			}
 		})
@@ -455,6 +492,181 @@ func TestJoin(t *testing.T) {
 	}
 }
 
+func TestToSliceInto(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		buf   []int
+		input []int
+		want  []int
+	}{
+		{
+			name:  "nil_buffer",
+			buf:   nil,
+			input: []int{1, 2},
+			want:  []int{1, 2},
+		},
+		{
+			name:  "appends_to_buffer",
+			buf:   []int{1},
+			input: []int{2, 3},
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "empty_input",
+			buf:   []int{1},
+			input: []int{},
+			want:  []int{1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ToSliceInto(slices.Values(tc.input), tc.buf)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToSliceIntoReusesCapacity(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]int, 0, 8)
+	got := ToSliceInto(slices.Values([]int{1, 2, 3}), buf)
+	if &got[0] != &buf[:1][0] {
+		t.Error("expected ToSliceInto to reuse the capacity of buf")
+	}
+}
+
+func TestCollectInto(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		m       map[string]int
+		input   map[string]int
+		resolve func(string, int, int) int
+		want    map[string]int
+	}{
+		{
+			name:    "nil_map",
+			m:       nil,
+			input:   map[string]int{"a": 1},
+			resolve: nil,
+			want:    map[string]int{"a": 1},
+		},
+		{
+			name:    "existing_keys_resolved",
+			m:       map[string]int{"a": 1, "b": 2},
+			input:   map[string]int{"a": 3, "c": 4},
+			resolve: func(_ string, existing, incoming int) int { return existing + incoming },
+			want:    map[string]int{"a": 4, "b": 2, "c": 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := CollectInto(maps.All(tc.input), tc.m, tc.resolve)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReduceByKey(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  map[byte]string
+	}{
+		{
+			name:  "empty",
+			input: []string{},
+			want:  map[byte]string{},
+		},
+		{
+			name:  "many",
+			input: []string{"apple", "banana", "avocado", "blueberry", "cherry"},
+			want:  map[byte]string{'a': "apple+avocado", 'b': "banana+blueberry", 'c': "cherry"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			keyed := func(yield func(byte, string) bool) {
+				for _, s := range tc.input {
+					if !yield(s[0], s) {
+						return
+					}
+				}
+			}
+			got := ReduceByKey(keyed, func(a, b string) string { return a + "+" + b })
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAggregateByKey(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		partitions [][]string
+		want       map[byte][]int
+	}{
+		{
+			name:       "no_partitions",
+			partitions: nil,
+			want:       map[byte][]int{},
+		},
+		{
+			name:       "one_partition",
+			partitions: [][]string{{"apple", "banana", "avocado"}},
+			want:       map[byte][]int{'a': {5, 7}, 'b': {6}},
+		},
+		{
+			name:       "many_partitions",
+			partitions: [][]string{{"apple", "banana"}, {"avocado", "cherry"}, {}},
+			want:       map[byte][]int{'a': {5, 7}, 'b': {6}, 'c': {6}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			partitions := slice.Map(tc.partitions, func(words []string) iter.Seq2[byte, string] {
+				return func(yield func(byte, string) bool) {
+					for _, s := range words {
+						if !yield(s[0], s) {
+							return
+						}
+					}
+				}
+			})
+			got := AggregateByKey(
+				func() []int { return nil },
+				func(lengths []int, s string) []int { return append(lengths, len(s)) },
+				func(a, b []int) []int { return append(a, b...) },
+				partitions...,
+			)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
 func TestZip(t *testing.T) {
 	t.Parallel()
 
@@ -564,7 +776,14 @@ func TestUnZip(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			input := maps.All(tc.input)
+			// iterate in key order, since map order is random
+			input := func(yield func(int, string) bool) {
+				for _, k := range slices.Sorted(maps.Keys(tc.input)) {
+					if !yield(k, tc.input[k]) {
+						return
+					}
+				}
+			}
 			unzippedLeft, unzippedRight := UnZip(input)
 			gotLeft, gotRight := slices.Collect(unzippedLeft), slices.Collect(unzippedRight)
 			if diff := cmp.Diff(gotLeft, tc.wantLeft); diff != "" {
@@ -960,32 +1179,306 @@ func TestPeek(t *testing.T) {
 	}
 }
 
-func DiffErr(got error, want error) string {
-	if got == nil && want == nil {
-		return ""
+func TestForEachIndexed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{
+			name:  "empty",
+			input: []string{},
+			want:  nil,
+		},
+		{
+			name:  "many",
+			input: []string{"a", "b", "c"},
+			want:  []string{"0:a", "1:b", "2:c"},
+		},
 	}
-	if got == nil {
-		return fmt.Sprintf("got error <nil> but want an error containing %q", want)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			ForEachIndexed(slices.Values(tc.input), func(i int, s string) {
+				got = append(got, fmt.Sprintf("%d:%s", i, s))
+			})
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestForEachWhile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			want:  nil,
+		},
+		{
+			name:  "never_stops",
+			input: []int{1, 2, 3},
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "stops_early",
+			input: []int{1, 2, 3, 4, 5},
+			want:  []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int
+			ForEachWhile(slices.Values(tc.input), func(i int) bool {
+				got = append(got, i)
+				return i < 3
+			})
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPeekEvery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		input        []int
+		n            int
+		want         []int
+		wantConsumed []int
+	}{
+		{
+			name:         "empty",
+			input:        []int{},
+			n:            2,
+			want:         nil,
+			wantConsumed: nil,
+		},
+		{
+			name:         "every_value",
+			input:        []int{1, 2, 3},
+			n:            1,
+			want:         []int{1, 2, 3},
+			wantConsumed: []int{1, 2, 3},
+		},
+		{
+			name:         "every_other_value",
+			input:        []int{1, 2, 3, 4, 5},
+			n:            2,
+			want:         []int{1, 2, 3, 4, 5},
+			wantConsumed: []int{1, 3, 5},
+		},
+		{
+			name:         "non_positive_n",
+			input:        []int{1, 2, 3},
+			n:            0,
+			want:         []int{1, 2, 3},
+			wantConsumed: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			consumer := &StatefulConsumer[int]{}
+			got := slices.Collect(PeekEvery(slices.Values(tc.input), tc.n, consumer.Consume))
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(consumer.Consumed(), tc.wantConsumed); diff != "" {
+				t.Errorf("unexpected result for consumed (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPeekSampled(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		input        []int
+		rate         float64
+		wantConsumed []int
+	}{
+		{
+			name:         "never",
+			input:        []int{1, 2, 3},
+			rate:         0,
+			wantConsumed: nil,
+		},
+		{
+			name:         "always",
+			input:        []int{1, 2, 3},
+			rate:         1,
+			wantConsumed: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			consumer := &StatefulConsumer[int]{}
+			got := slices.Collect(PeekSampled(slices.Values(tc.input), tc.rate, consumer.Consume))
+			if diff := cmp.Diff(got, tc.input); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(consumer.Consumed(), tc.wantConsumed); diff != "" {
+				t.Errorf("unexpected result for consumed (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCountAtMost(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		input        []int
+		max          int64
+		want         int64
+		wantConsumed []int
+	}{
+		{
+			name:         "empty",
+			input:        []int{},
+			max:          3,
+			want:         0,
+			wantConsumed: nil,
+		},
+		{
+			name:         "fewer_than_max",
+			input:        []int{1, 2},
+			max:          3,
+			want:         2,
+			wantConsumed: []int{1, 2},
+		},
+		{
+			name:         "more_than_max",
+			input:        []int{1, 2, 3, 4, 5},
+			max:          3,
+			want:         3,
+			wantConsumed: []int{1, 2, 3},
+		},
+		{
+			name:         "zero_max",
+			input:        []int{1, 2, 3},
+			max:          0,
+			want:         0,
+			wantConsumed: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var consumed []int
+			got := CountAtMost(Peek(slices.Values(tc.input), func(i int) { consumed = append(consumed, i) }), tc.max)
+			if got != tc.want {
+				t.Errorf("unexpected result: got %d, want %d", got, tc.want)
+			}
+			if diff := cmp.Diff(consumed, tc.wantConsumed); diff != "" {
+				t.Errorf("unexpected result for consumed (-got, +want): %s", diff)
+			}
+		})
 	}
-	if want == nil {
-		return fmt.Sprintf("got error %q but want an error <nil>", got)
+}
+
+func TestCountAtMostInfinite(t *testing.T) {
+	t.Parallel()
+
+	if got := CountAtMost(Generate(func() int { return 1 }), 5); got != 5 {
+		t.Errorf("unexpected result: got %d, want 5", got)
 	}
-	if gotMsg, wantMsg := got.Error(), want.Error(); !strings.Contains(gotMsg, wantMsg) {
-		out := fmt.Sprintf("got error %q but want an error containing %q", gotMsg, want)
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
 
-		// For long strings that will be hard to visually diff, include a diff.
-		// Explanation of the &&'s and ||'s: if we're diffing a long error
-		// message against a short one, a detailed diff isn't needed. The
-		// difference will be obvious to the eye, and any extra message will
-		// just be clutter. So only show the extra diff if the messages are both
-		// long, or both multi-line.
-		const msgLen = 20 // chosen arbitrarily
-		bothAreLong := len(wantMsg) >= msgLen && len(gotMsg) >= msgLen
-		bothAreMultiline := strings.Contains(wantMsg, "\n") && strings.Contains(gotMsg, "\n")
-		if bothAreLong || bothAreMultiline {
-			out += fmt.Sprintf("; diff was (-got,+want):\n%s", cmp.Diff(gotMsg, want))
-		}
-		return out
+	errFetch := errors.New("fetch failed")
+	pages := [][]int{{1, 2}, {3}, {}, {4, 5}}
+	cases := []struct {
+		name string
+		// failAt is the cursor at which fetch fails, or -1 to never fail
+		failAt      int
+		limit       int
+		want        []int
+		wantErr     error
+		wantFetches int
+	}{
+		{
+			name:        "all_pages",
+			failAt:      -1,
+			limit:       100,
+			want:        []int{1, 2, 3, 4, 5},
+			wantFetches: 4,
+		},
+		{
+			name:        "stops_early",
+			failAt:      -1,
+			limit:       3,
+			want:        []int{1, 2, 3},
+			wantFetches: 2,
+		},
+		{
+			name:        "fetch_fails",
+			failAt:      1,
+			limit:       100,
+			want:        []int{1, 2, 3},
+			wantErr:     errFetch,
+			wantFetches: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fetches := 0
+			fetch := func(cursor int) ([]int, int, bool, error) {
+				fetches++
+				if cursor == tc.failAt {
+					return pages[cursor], 0, false, errFetch
+				}
+				return pages[cursor], cursor + 1, cursor == len(pages)-1, nil
+			}
+			var got []int
+			var gotErr error
+			for i, err := range Paginate(fetch) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, i)
+				if len(got) == tc.limit {
+					break
+				}
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if !errors.Is(gotErr, tc.wantErr) {
+				t.Errorf("got error %v, want %v", gotErr, tc.wantErr)
+			}
+			if fetches != tc.wantFetches {
+				t.Errorf("unexpected number of fetches: got %d, want %d", fetches, tc.wantFetches)
+			}
+		})
 	}
-	return ""
 }
@@ -0,0 +1,54 @@
+package iterator
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"iter"
+)
+
+// WriteWith writes format(t) for every value t of itr to w. Writes are
+// buffered and flushed before returning, along with w itself if it has a Flush
+// method, like *bufio.Writer. Writing stops at the first error, which is
+// returned after flushing what was written before it.
+func WriteWith[T any](w io.Writer, itr iter.Seq[T], format func(T) []byte) error {
+	return writeWith(w, itr, func(t T) ([]byte, error) { return format(t), nil })
+}
+
+// WriteLines writes every value of itr to w as a line, see WriteWith.
+func WriteLines[S ~string](w io.Writer, itr iter.Seq[S]) error {
+	return WriteWith(w, itr, func(s S) []byte { return append([]byte(s), '\n') })
+}
+
+// WriteJSONLines writes every value of itr to w as a line of JSON, see
+// WriteWith. A value that cannot be encoded stops writing like a failed write.
+func WriteJSONLines[T any](w io.Writer, itr iter.Seq[T]) error {
+	return writeWith(w, itr, func(t T) ([]byte, error) {
+		line, err := json.Marshal(t)
+		return append(line, '\n'), err
+	})
+}
+
+func writeWith[T any](w io.Writer, itr iter.Seq[T], format func(T) ([]byte, error)) error {
+	buffered := bufio.NewWriter(w)
+	var err error
+	for t := range itr {
+		var b []byte
+		if b, err = format(t); err == nil {
+			_, err = buffered.Write(b)
+		}
+		if err != nil {
+			break
+		}
+	}
+	// flush whatever was written before any error
+	if flushErr := buffered.Flush(); err == nil {
+		err = flushErr
+	}
+	if flusher, ok := w.(interface{ Flush() error }); ok {
+		if flushErr := flusher.Flush(); err == nil {
+			err = flushErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package iterator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWriteLines(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  string
+	}{
+		{
+			name:  "empty",
+			input: []string{},
+			want:  "",
+		},
+		{
+			name:  "many",
+			input: []string{"a", "b", "c"},
+			want:  "a\nb\nc\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := WriteLines(&buf, slices.Values(tc.input)); err != nil {
+				t.Fatalf("got error %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(buf.String(), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	t.Parallel()
+
+	type point struct {
+		X, Y float64
+	}
+	cases := []struct {
+		name    string
+		input   []point
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "many",
+			input: []point{{X: 1, Y: 2}, {X: 3, Y: 4}},
+			want:  "{\"X\":1,\"Y\":2}\n{\"X\":3,\"Y\":4}\n",
+		},
+		{
+			name:    "unencodable",
+			input:   []point{{X: 1, Y: 2}, {X: math.NaN()}, {X: 3, Y: 4}},
+			want:    "{\"X\":1,\"Y\":2}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			err := WriteJSONLines(&buf, slices.Values(tc.input))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(buf.String(), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteWithFlushesWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := WriteWith(w, slices.Values([]int{1, 2, 3}), func(i int) []byte { return []byte{byte('0' + i)} })
+	if err != nil {
+		t.Fatalf("got error %v, want %v", err, nil)
+	}
+	if diff := cmp.Diff(buf.String(), "123"); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestWriteWithError(t *testing.T) {
+	t.Parallel()
+
+	err := WriteLines(failingWriter{}, slices.Values([]string{"a", "b"}))
+	if !errors.Is(err, errWrite) {
+		t.Errorf("got error %v, want %v", err, errWrite)
+	}
+}
@@ -0,0 +1,221 @@
+package maps
+
+import (
+	"github.com/lock14/functional/tuple"
+	"iter"
+)
+
+func Map[K comparable, V, U any](m map[K]V, f func(V) U) map[K]U {
+	mapped := make(map[K]U, len(m))
+	for k, v := range m {
+		mapped[k] = f(v)
+	}
+	return mapped
+}
+
+// MapKV transforms every entry of m with f. If f produces the same key for
+// several entries, which of them ends up in the result is unspecified.
+func MapKV[K1, K2 comparable, V1, V2 any](m map[K1]V1, f func(K1, V1) (K2, V2)) map[K2]V2 {
+	mapped := make(map[K2]V2, len(m))
+	for k1, v1 := range m {
+		k2, v2 := f(k1, v1)
+		mapped[k2] = v2
+	}
+	return mapped
+}
+
+// MapKeys transforms the keys of m with f. When f maps several keys to the
+// same key, resolve is called with the new key, the value already in the
+// result, and the incoming value to determine the value to keep. Since map
+// iteration order is random, resolve should not depend on which value arrives
+// first. A nil resolve keeps an arbitrary one of the colliding values.
+func MapKeys[K1, K2 comparable, V any](m map[K1]V, f func(K1) K2, resolve func(k K2, existing, incoming V) V) map[K2]V {
+	mapped := make(map[K2]V, len(m))
+	for k1, v := range m {
+		k2 := f(k1)
+		if existing, ok := mapped[k2]; ok && resolve != nil {
+			mapped[k2] = resolve(k2, existing, v)
+		} else {
+			mapped[k2] = v
+		}
+	}
+	return mapped
+}
+
+func MapValues[K comparable, V, U any](m map[K]V, f func(V) U) map[K]U {
+	return Map(m, f)
+}
+
+// Invert swaps the keys and values of m. If several keys share a value, which
+// of them ends up in the result is unspecified.
+func Invert[K, V comparable](m map[K]V) map[V]K {
+	return MapKV(m, func(k K, v V) (V, K) { return v, k })
+}
+
+func Filter[K comparable, V any](m map[K]V, p func(K, V) bool) map[K]V {
+	filtered := make(map[K]V)
+	for k, v := range m {
+		if p(k, v) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+func FilterKeys[K comparable, V any](m map[K]V, p func(K) bool) map[K]V {
+	return Filter(m, func(k K, _ V) bool { return p(k) })
+}
+
+func FilterValues[K comparable, V any](m map[K]V, p func(V) bool) map[K]V {
+	return Filter(m, func(_ K, v V) bool { return p(v) })
+}
+
+// IntersectKeys returns the entries of m whose key is also in other.
+func IntersectKeys[K comparable, V, W any](m map[K]V, other map[K]W) map[K]V {
+	return FilterKeys(m, func(k K) bool {
+		_, ok := other[k]
+		return ok
+	})
+}
+
+// DifferenceKeys returns the entries of m whose key is not in other.
+func DifferenceKeys[K comparable, V, W any](m map[K]V, other map[K]W) map[K]V {
+	return FilterKeys(m, func(k K) bool {
+		_, ok := other[k]
+		return !ok
+	})
+}
+
+// UnionKeys returns the entries of all of ms. When a key appears in more than
+// one map, the value from the first of them is kept.
+func UnionKeys[K comparable, V any](ms ...map[K]V) map[K]V {
+	return Merge(KeepFirst[K, V], ms...)
+}
+
+// FoldLeft folds the entries of m into u. Map iteration order is random, so f
+// should not depend on the order in which entries are visited.
+func FoldLeft[K comparable, V, U any](m map[K]V, f func(u U, k K, v V) U, u U) U {
+	result := u
+	for k, v := range m {
+		result = f(result, k, v)
+	}
+	return result
+}
+
+func ForEach[K comparable, V any](m map[K]V, f func(K, V)) {
+	for k, v := range m {
+		f(k, v)
+	}
+}
+
+func Count[K comparable, V any](m map[K]V, p func(K, V) bool) int {
+	return FoldLeft(m, func(count int, k K, v V) int {
+		if p(k, v) {
+			count++
+		}
+		return count
+	}, 0)
+}
+
+// Merge combines ms into a new map. When a key appears in more than one map,
+// resolve is called with the key, the value merged so far, and the value from
+// the later map to determine the value to keep. A nil resolve keeps the last
+// value.
+func Merge[K comparable, V any](resolve func(k K, existing, incoming V) V, ms ...map[K]V) map[K]V {
+	merged, _ := MergeErr(func(k K, existing, incoming V) (V, error) {
+		if resolve == nil {
+			return incoming, nil
+		}
+		return resolve(k, existing, incoming), nil
+	}, ms...)
+	return merged
+}
+
+// MergeErr is like Merge, but stops at and returns the first error produced
+// by resolve.
+func MergeErr[K comparable, V any](resolve func(k K, existing, incoming V) (V, error), ms ...map[K]V) (map[K]V, error) {
+	size := 0
+	for _, m := range ms {
+		size += len(m)
+	}
+	merged := make(map[K]V, size)
+	for _, m := range ms {
+		for k, v := range m {
+			if existing, ok := merged[k]; ok {
+				resolved, err := resolve(k, existing, v)
+				if err != nil {
+					return nil, err
+				}
+				merged[k] = resolved
+			} else {
+				merged[k] = v
+			}
+		}
+	}
+	return merged, nil
+}
+
+// KeepFirst is a conflict resolver for Merge and the ToMap functions that
+// keeps the value already present.
+func KeepFirst[K, V any](_ K, existing, _ V) V {
+	return existing
+}
+
+// KeepLast is a conflict resolver for Merge and the ToMap functions that keeps
+// the incoming value.
+func KeepLast[K, V any](_ K, _, incoming V) V {
+	return incoming
+}
+
+// ToPairs returns the entries of m as pairs, in unspecified order.
+func ToPairs[K comparable, V any](m map[K]V) []tuple.Pair[K, V] {
+	pairs := make([]tuple.Pair[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, tuple.Pair[K, V]{Fst: k, Snd: v})
+	}
+	return pairs
+}
+
+// FromPairs collects pairs into a map. When a key occurs more than once, the
+// last value is kept.
+func FromPairs[K comparable, V any](pairs []tuple.Pair[K, V]) map[K]V {
+	m := make(map[K]V, len(pairs))
+	for _, p := range pairs {
+		m[p.Fst] = p.Snd
+	}
+	return m
+}
+
+// FromSeq2 collects seq into a map. When a key occurs more than once, the
+// last value is kept.
+func FromSeq2[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	m := make(map[K]V)
+	for k, v := range seq {
+		m[k] = v
+	}
+	return m
+}
+
+// GroupedBy collects seq into a map from each key to the values that occurred
+// with it, in the order they occurred.
+func GroupedBy[K comparable, V any](seq iter.Seq2[K, V]) map[K][]V {
+	groups := make(map[K][]V)
+	for k, v := range seq {
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Ungroup yields every value of groups together with its key. Keys are
+// visited in unspecified order; the values of each key are yielded in order.
+func Ungroup[K comparable, V any](groups map[K][]V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, vs := range groups {
+			for _, v := range vs {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
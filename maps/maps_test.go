@@ -0,0 +1,370 @@
+package maps
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lock14/functional/slice"
+	"github.com/lock14/functional/tuple"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input map[string]int
+		want  map[string]string
+	}{
+		{
+			name:  "map_empty",
+			input: map[string]int{},
+			want:  map[string]string{},
+		},
+		{
+			name:  "map_many",
+			input: map[string]int{"a": 1, "b": 2},
+			want:  map[string]string{"a": "1", "b": "2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Map(tc.input, strconv.Itoa)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapKV(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]int{"a": 1, "b": 2}
+	got := MapKV(input, func(k string, v int) (int, string) { return v, k })
+	want := map[int]string{1: "a", 2: "b"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input map[string]int
+		p     func(string, int) bool
+		want  map[string]int
+	}{
+		{
+			name:  "filter_none",
+			input: map[string]int{"a": 1, "b": 2},
+			p:     func(string, int) bool { return false },
+			want:  map[string]int{},
+		},
+		{
+			name:  "filter_some",
+			input: map[string]int{"a": 1, "b": 2, "c": 3},
+			p:     func(k string, v int) bool { return k == "a" || v == 3 },
+			want:  map[string]int{"a": 1, "c": 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Filter(tc.input, tc.p)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestFoldLeft(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]int{"a": 1, "bb": 2, "ccc": 3}
+	got := FoldLeft(input, func(sum int, k string, v int) int { return sum + len(k)*v }, 0)
+	if diff := cmp.Diff(got, 14); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]int{"a": 1, "b": 2}
+	got := make(map[string]int)
+	ForEach(input, func(k string, v int) { got[k] = v })
+	if diff := cmp.Diff(got, input); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	got := Count(input, func(_ string, v int) bool { return v%2 == 0 })
+	if diff := cmp.Diff(got, 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+
+	sum := func(_ int, existing, incoming int) int { return existing + incoming }
+	cases := []struct {
+		name    string
+		input   map[string]int
+		resolve func(int, int, int) int
+		want    map[int]int
+	}{
+		{
+			name:  "map_keys_no_collision",
+			input: map[string]int{"a": 1, "bb": 2},
+			want:  map[int]int{1: 1, 2: 2},
+		},
+		{
+			name:    "map_keys_collision",
+			input:   map[string]int{"a": 1, "b": 2, "cc": 3},
+			resolve: sum,
+			want:    map[int]int{1: 3, 2: 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := MapKeys(tc.input, func(k string) int { return len(k) }, tc.resolve)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	t.Parallel()
+
+	got := MapValues(map[string]int{"a": 1, "b": 2}, func(v int) int { return v * 10 })
+	want := map[string]int{"a": 10, "b": 20}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	t.Parallel()
+
+	got := Invert(map[string]int{"a": 1, "b": 2})
+	want := map[int]string{1: "a", 2: "b"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		input   []map[string]int
+		resolve func(string, int, int) int
+		want    map[string]int
+	}{
+		{
+			name:  "merge_none",
+			input: nil,
+			want:  map[string]int{},
+		},
+		{
+			name:  "merge_nil_resolve",
+			input: []map[string]int{{"a": 1, "b": 2}, {"b": 3}},
+			want:  map[string]int{"a": 1, "b": 3},
+		},
+		{
+			name:    "merge_keep_first",
+			input:   []map[string]int{{"a": 1, "b": 2}, {"b": 3}, {"b": 4, "c": 5}},
+			resolve: KeepFirst[string, int],
+			want:    map[string]int{"a": 1, "b": 2, "c": 5},
+		},
+		{
+			name:    "merge_keep_last",
+			input:   []map[string]int{{"a": 1, "b": 2}, {"b": 3}, {"b": 4, "c": 5}},
+			resolve: KeepLast[string, int],
+			want:    map[string]int{"a": 1, "b": 4, "c": 5},
+		},
+		{
+			name:    "merge_sum",
+			input:   []map[string]int{{"a": 1, "b": 2}, {"b": 3}, {"b": 4, "c": 5}},
+			resolve: func(_ string, existing, incoming int) int { return existing + incoming },
+			want:    map[string]int{"a": 1, "b": 9, "c": 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Merge(tc.resolve, tc.input...)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeErr(t *testing.T) {
+	t.Parallel()
+
+	errConflict := errors.New("conflict")
+	reject := func(string, int, int) (int, error) { return 0, errConflict }
+
+	got, err := MergeErr(reject, map[string]int{"a": 1}, map[string]int{"b": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(got, map[string]int{"a": 1, "b": 2}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+
+	if _, err := MergeErr(reject, map[string]int{"a": 1}, map[string]int{"a": 2}); !errors.Is(err, errConflict) {
+		t.Errorf("got error %v, want %v", err, errConflict)
+	}
+}
+
+func TestFilterKeysAndValues(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	gotKeys := FilterKeys(input, func(k string) bool { return k != "b" })
+	if diff := cmp.Diff(gotKeys, map[string]int{"a": 1, "c": 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+
+	gotValues := FilterValues(input, func(v int) bool { return v > 1 })
+	if diff := cmp.Diff(gotValues, map[string]int{"b": 2, "c": 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestKeySetOps(t *testing.T) {
+	t.Parallel()
+
+	m1 := map[string]int{"a": 1, "b": 2, "c": 3}
+	m2 := map[string]bool{"b": true, "c": false, "d": true}
+	m3 := map[string]int{"c": 30, "d": 40}
+
+	cases := []struct {
+		name string
+		got  map[string]int
+		want map[string]int
+	}{
+		{
+			name: "intersect_keys",
+			got:  IntersectKeys(m1, m2),
+			want: map[string]int{"b": 2, "c": 3},
+		},
+		{
+			name: "difference_keys",
+			got:  DifferenceKeys(m1, m2),
+			want: map[string]int{"a": 1},
+		},
+		{
+			name: "union_keys",
+			got:  UnionKeys(m1, m3),
+			want: map[string]int{"a": 1, "b": 2, "c": 3, "d": 40},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToPairs(t *testing.T) {
+	t.Parallel()
+
+	got := ToPairs(map[string]int{"a": 1, "b": 2})
+	want := []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "b", Snd: 2}}
+	sortPairs := cmpopts.SortSlices(func(p1, p2 tuple.Pair[string, int]) bool { return p1.Fst < p2.Fst })
+	if diff := cmp.Diff(got, want, sortPairs); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestFromPairs(t *testing.T) {
+	t.Parallel()
+
+	input := slice.Zip([]string{"a", "b", "a"}, []int{1, 2, 3})
+	got := FromPairs(input)
+	want := map[string]int{"a": 3, "b": 2}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestFromSeq2(t *testing.T) {
+	t.Parallel()
+
+	got := FromSeq2(slices.All([]string{"a", "b"}))
+	want := map[int]string{0: "a", 1: "b"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestGroupedByAndUngroup(t *testing.T) {
+	t.Parallel()
+
+	input := map[string][]int{"a": {1, 2}, "b": {3}, "c": {}}
+	var pairs []tuple.Pair[string, int]
+	for k, v := range Ungroup(input) {
+		pairs = append(pairs, tuple.Pair[string, int]{Fst: k, Snd: v})
+	}
+	wantPairs := []tuple.Pair[string, int]{{Fst: "a", Snd: 1}, {Fst: "a", Snd: 2}, {Fst: "b", Snd: 3}}
+	sortPairs := cmpopts.SortSlices(func(p1, p2 tuple.Pair[string, int]) bool {
+		return p1.Fst < p2.Fst || p1.Fst == p2.Fst && p1.Snd < p2.Snd
+	})
+	if diff := cmp.Diff(pairs, wantPairs, sortPairs); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+
+	got := GroupedBy(Ungroup(input))
+	want := map[string][]int{"a": {1, 2}, "b": {3}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestUngroupStopsEarly(t *testing.T) {
+	t.Parallel()
+
+	count := 0
+	for range Ungroup(map[string][]int{"a": {1, 2, 3}}) {
+		count++
+		break
+	}
+	if diff := cmp.Diff(count, 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
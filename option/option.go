@@ -0,0 +1,79 @@
+package option
+
+// Option holds either a value or nothing. The zero Option is None.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+func Some[T any](t T) Option[T] {
+	return Option[T]{value: t, ok: true}
+}
+
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// FromPtr returns None for a nil pointer and Some of the pointed-to value
+// otherwise.
+func FromPtr[T any](ptr *T) Option[T] {
+	if ptr == nil {
+		return None[T]()
+	}
+	return Some(*ptr)
+}
+
+// FromPair adapts the (T, bool) results used throughout the library.
+func FromPair[T any](t T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(t)
+}
+
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+func (o Option[T]) OrElse(t T) T {
+	if o.ok {
+		return o.value
+	}
+	return t
+}
+
+func (o Option[T]) OrElseGet(supplier func() T) T {
+	if o.ok {
+		return o.value
+	}
+	return supplier()
+}
+
+func Map[T, U any](o Option[T], f func(T) U) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.value))
+}
+
+func FlatMap[T, U any](o Option[T], f func(T) Option[U]) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return f(o.value)
+}
+
+func Filter[T any](o Option[T], p func(T) bool) Option[T] {
+	if !o.ok || !p(o.value) {
+		return None[T]()
+	}
+	return o
+}
@@ -0,0 +1,104 @@
+package option
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"strconv"
+	"testing"
+)
+
+type values[T any] struct {
+	Value T
+	Ok    bool
+}
+
+func get[T any](o Option[T]) values[T] {
+	v, ok := o.Get()
+	return values[T]{Value: v, Ok: ok}
+}
+
+func TestConstructors(t *testing.T) {
+	t.Parallel()
+
+	five := 5
+	cases := []struct {
+		name  string
+		input Option[int]
+		want  values[int]
+	}{
+		{name: "some", input: Some(1), want: values[int]{Value: 1, Ok: true}},
+		{name: "none", input: None[int](), want: values[int]{}},
+		{name: "zero", input: Option[int]{}, want: values[int]{}},
+		{name: "from_ptr_nil", input: FromPtr[int](nil), want: values[int]{}},
+		{name: "from_ptr", input: FromPtr(&five), want: values[int]{Value: 5, Ok: true}},
+		{name: "from_pair_false", input: FromPair(3, false), want: values[int]{}},
+		{name: "from_pair_true", input: FromPair(3, true), want: values[int]{Value: 3, Ok: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(get(tc.input), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.input.IsSome(), tc.want.Ok); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.input.IsNone(), !tc.want.Ok); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff(Some(1).OrElse(2), 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(None[int]().OrElse(2), 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(None[int]().OrElseGet(func() int { return 3 }), 3); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	Some(1).OrElseGet(func() int {
+		t.Error("supplier was called when it should not have been")
+		return 0
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) Option[int] {
+		i, err := strconv.Atoi(s)
+		return FromPair(i, err == nil)
+	}
+	even := func(i int) bool { return i%2 == 0 }
+
+	cases := []struct {
+		name string
+		got  Option[string]
+		want values[string]
+	}{
+		{name: "map_some", got: Map(Some(1), strconv.Itoa), want: values[string]{Value: "1", Ok: true}},
+		{name: "map_none", got: Map(None[int](), strconv.Itoa), want: values[string]{}},
+		{name: "flat_map_some", got: Map(FlatMap(Some("12"), parse), strconv.Itoa), want: values[string]{Value: "12", Ok: true}},
+		{name: "flat_map_fails", got: Map(FlatMap(Some("x"), parse), strconv.Itoa), want: values[string]{}},
+		{name: "flat_map_none", got: Map(FlatMap(None[string](), parse), strconv.Itoa), want: values[string]{}},
+		{name: "filter_keeps", got: Map(Filter(Some(2), even), strconv.Itoa), want: values[string]{Value: "2", Ok: true}},
+		{name: "filter_drops", got: Map(Filter(Some(3), even), strconv.Itoa), want: values[string]{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(get(tc.got), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package predicate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// nilSink keeps the compiler from optimizing the benchmarked calls away.
+var nilSink bool
+
+// reflectIsNil is the reflection based check that IsNil and Nil avoid, kept as
+// the baseline of the benchmarks.
+func reflectIsNil[T any](t T) bool {
+	switch rv := reflect.ValueOf(t); rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+func benchmarkNil[T any](b *testing.B, t T) {
+	b.Run("reflect", func(b *testing.B) {
+		for range b.N {
+			nilSink = reflectIsNil(t)
+		}
+	})
+	b.Run("IsNil", func(b *testing.B) {
+		for range b.N {
+			nilSink = IsNil(t)
+		}
+	})
+	b.Run("Nil", func(b *testing.B) {
+		isNil := Nil[T]()
+		for range b.N {
+			nilSink = isNil(t)
+		}
+	})
+}
+
+func BenchmarkNilPointer(b *testing.B) {
+	benchmarkNil(b, new(int))
+}
+
+func BenchmarkNilSlice(b *testing.B) {
+	benchmarkNil(b, []int{1, 2, 3})
+}
+
+func BenchmarkNilMap(b *testing.B) {
+	benchmarkNil(b, map[string]int{})
+}
@@ -0,0 +1,50 @@
+package predicate
+
+import "slices"
+
+func IsEmpty[S ~[]E, E any](s S) bool {
+	return len(s) == 0
+}
+
+func IsEmptyMap[M ~map[K]V, K comparable, V any](m M) bool {
+	return len(m) == 0
+}
+
+// HasLen returns a predicate that holds for slices of length n. The slice
+// type cannot be inferred, so it must be given, as in HasLen[[]int](3).
+func HasLen[S ~[]E, E any](n int) func(S) bool {
+	return func(s S) bool {
+		return len(s) == n
+	}
+}
+
+func HasLenMap[M ~map[K]V, K comparable, V any](n int) func(M) bool {
+	return func(m M) bool {
+		return len(m) == n
+	}
+}
+
+func LongerThan[S ~[]E, E any](n int) func(S) bool {
+	return func(s S) bool {
+		return len(s) > n
+	}
+}
+
+func LongerThanMap[M ~map[K]V, K comparable, V any](n int) func(M) bool {
+	return func(m M) bool {
+		return len(m) > n
+	}
+}
+
+func ContainsElement[S ~[]E, E comparable](v E) func(S) bool {
+	return func(s S) bool {
+		return slices.Contains(s, v)
+	}
+}
+
+func ContainsKey[M ~map[K]V, K comparable, V any](k K) func(M) bool {
+	return func(m M) bool {
+		_, ok := m[k]
+		return ok
+	}
+}
@@ -0,0 +1,68 @@
+package predicate
+
+import (
+	"testing"
+)
+
+func TestSlicePredicates(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		p     func([]int) bool
+		input []int
+		want  bool
+	}{
+		{name: "is_empty_nil", p: IsEmpty[[]int], input: nil, want: true},
+		{name: "is_empty_empty", p: IsEmpty[[]int], input: []int{}, want: true},
+		{name: "is_empty_non_empty", p: IsEmpty[[]int], input: []int{0}, want: false},
+		{name: "has_len", p: HasLen[[]int](2), input: []int{1, 2}, want: true},
+		{name: "has_len_other", p: HasLen[[]int](2), input: []int{1}, want: false},
+		{name: "has_len_zero_nil", p: HasLen[[]int](0), input: nil, want: true},
+		{name: "longer_than", p: LongerThan[[]int](1), input: []int{1, 2}, want: true},
+		{name: "longer_than_equal", p: LongerThan[[]int](2), input: []int{1, 2}, want: false},
+		{name: "contains_element", p: ContainsElement[[]int](2), input: []int{1, 2}, want: true},
+		{name: "contains_element_missing", p: ContainsElement[[]int](3), input: []int{1, 2}, want: false},
+		{name: "contains_element_nil", p: ContainsElement[[]int](0), input: nil, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.p(tc.input); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMapPredicates(t *testing.T) {
+	t.Parallel()
+
+	type counts map[string]int
+	cases := []struct {
+		name  string
+		p     func(counts) bool
+		input counts
+		want  bool
+	}{
+		{name: "is_empty_nil", p: IsEmptyMap[counts], input: nil, want: true},
+		{name: "is_empty_non_empty", p: IsEmptyMap[counts], input: counts{"a": 0}, want: false},
+		{name: "has_len", p: HasLenMap[counts](1), input: counts{"a": 0}, want: true},
+		{name: "has_len_other", p: HasLenMap[counts](2), input: counts{"a": 0}, want: false},
+		{name: "longer_than", p: LongerThanMap[counts](0), input: counts{"a": 0}, want: true},
+		{name: "longer_than_equal", p: LongerThanMap[counts](1), input: counts{"a": 0}, want: false},
+		{name: "contains_key", p: ContainsKey[counts]("a"), input: counts{"a": 0}, want: true},
+		{name: "contains_key_missing", p: ContainsKey[counts]("b"), input: counts{"a": 0}, want: false},
+		{name: "contains_key_nil", p: ContainsKey[counts]("a"), input: nil, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.p(tc.input); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,90 @@
 package predicate
 
-import "reflect"
+import (
+	"cmp"
+	"reflect"
+	"unsafe"
+)
 
+// IsNil reports whether t is nil. A nil interface value, as well as an
+// interface holding a nil pointer, map, slice, channel or function, is nil.
+// Unless T is an interface type, t is checked without reflection; the
+// predicate returned by Nil also resolves the kind of T only once.
 func IsNil[T any](t T) bool {
-	switch reflect.ValueOf(t).Type().Kind() {
+	return isNil(reflect.TypeFor[T]().Kind(), t)
+}
+
+// Nil returns IsNil for T, with the kind of T resolved once, for checking
+// many values of the same type, such as in a Filter.
+func Nil[T any]() func(T) bool {
+	switch kind := reflect.TypeFor[T]().Kind(); kind {
+	case reflect.Interface:
+		return func(t T) bool {
+			return isNilInterface(any(t))
+		}
+	default:
+		if !nillable(kind) {
+			return False[T]
+		}
+		return func(t T) bool {
+			return firstWordNil(&t)
+		}
+	}
+}
+
+// isNil reports whether t, of the given kind, is nil.
+func isNil[T any](kind reflect.Kind, t T) bool {
+	switch {
+	case kind == reflect.Interface:
+		return isNilInterface(any(t))
+	case nillable(kind):
+		return firstWordNil(&t)
+	default:
+		return false
+	}
+}
+
+// nillable reports whether values of kind, other than interfaces, can be nil.
+func nillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer,
+		reflect.UnsafePointer, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// firstWordNil reports whether the first word of *p is nil. For the nillable
+// kinds this is the pointer they refer to or, for a slice, its data pointer,
+// which is nil exactly when the value is.
+func firstWordNil[T any](p *T) bool {
+	return *(*unsafe.Pointer)(unsafe.Pointer(p)) == nil
+}
+
+// isNilInterface reports whether v is nil or holds a nil value.
+func isNilInterface(v any) bool {
+	switch v.(type) {
+	case nil:
+		return true
+	case bool, string, int, int8, int16, int32, int64, uint, uint8, uint16,
+		uint32, uint64, uintptr, float32, float64, complex64, complex128:
+		return false
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
 	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer,
 		reflect.UnsafePointer, reflect.Interface, reflect.Slice:
-		return reflect.ValueOf(t).IsNil()
+		return rv.IsNil()
 	default:
 		return false
 	}
 }
 
+func IsZero[T comparable](t T) bool {
+	var zero T
+	return t == zero
+}
+
 func NotNil[T any](t T) bool {
 	return !IsNil(t)
 }
@@ -29,3 +102,126 @@ func True[T any](t T) bool {
 func False[T any](t T) bool {
 	return false
 }
+
+func And[T any](p1, p2 func(T) bool) func(T) bool {
+	return func(t T) bool {
+		return p1(t) && p2(t)
+	}
+}
+
+func Or[T any](p1, p2 func(T) bool) func(T) bool {
+	return func(t T) bool {
+		return p1(t) || p2(t)
+	}
+}
+
+func Xor[T any](p1, p2 func(T) bool) func(T) bool {
+	return func(t T) bool {
+		return p1(t) != p2(t)
+	}
+}
+
+// AllOf returns a predicate that holds if every one of ps holds, evaluating
+// them in order and stopping at the first that does not. AllOf with no
+// predicates always holds.
+func AllOf[T any](ps ...func(T) bool) func(T) bool {
+	return func(t T) bool {
+		for _, p := range ps {
+			if !p(t) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf returns a predicate that holds if any of ps holds, evaluating them in
+// order and stopping at the first that does. AnyOf with no predicates never
+// holds.
+func AnyOf[T any](ps ...func(T) bool) func(T) bool {
+	return func(t T) bool {
+		for _, p := range ps {
+			if p(t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func NoneOf[T any](ps ...func(T) bool) func(T) bool {
+	return Not(AnyOf(ps...))
+}
+
+func EqualTo[T comparable](v T) func(T) bool {
+	return func(t T) bool {
+		return t == v
+	}
+}
+
+// DeepEquals is like EqualTo for types that are not comparable, using
+// reflect.DeepEqual.
+func DeepEquals[T any](v T) func(T) bool {
+	return func(t T) bool {
+		return reflect.DeepEqual(t, v)
+	}
+}
+
+// In returns a predicate that holds for the keys of m, such as the elements
+// of a set.Set.
+func In[M ~map[T]V, T comparable, V any](m M) func(T) bool {
+	return func(t T) bool {
+		_, ok := m[t]
+		return ok
+	}
+}
+
+// OneOf returns a predicate that holds for any of vs. Pass a slice as
+// OneOf(s...).
+func OneOf[T comparable](vs ...T) func(T) bool {
+	set := make(map[T]struct{}, len(vs))
+	for _, v := range vs {
+		set[v] = struct{}{}
+	}
+	return In(set)
+}
+
+func GreaterThan[T cmp.Ordered](v T) func(T) bool {
+	return func(t T) bool {
+		return t > v
+	}
+}
+
+func GreaterEq[T cmp.Ordered](v T) func(T) bool {
+	return func(t T) bool {
+		return t >= v
+	}
+}
+
+func LessThan[T cmp.Ordered](v T) func(T) bool {
+	return func(t T) bool {
+		return t < v
+	}
+}
+
+func LessEq[T cmp.Ordered](v T) func(T) bool {
+	return func(t T) bool {
+		return t <= v
+	}
+}
+
+// Between returns a predicate that holds for values in the closed range
+// [low, high].
+func Between[T cmp.Ordered](low, high T) func(T) bool {
+	return func(t T) bool {
+		return low <= t && t <= high
+	}
+}
+
+// By returns a predicate that holds for values whose key, as extracted by
+// extract, satisfies p. For example, By(User.Age, GreaterThan(18)).
+func By[T, K any](extract func(T) K, p func(K) bool) func(T) bool {
+	return func(t T) bool {
+		return p(extract(t))
+	}
+}
@@ -0,0 +1,269 @@
+package predicate
+
+import (
+	"errors"
+	"testing"
+)
+
+var errTest = errors.New("test")
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(i int) bool { return i%2 == 0 }
+	isPositive := func(i int) bool { return i > 0 }
+	isSmall := func(i int) bool { return i < 10 }
+	cases := []struct {
+		name  string
+		p     func(int) bool
+		input int
+		want  bool
+	}{
+		{name: "not_true", p: Not(isEven), input: 2, want: false},
+		{name: "not_false", p: Not(isEven), input: 1, want: true},
+		{name: "true", p: True[int], input: 0, want: true},
+		{name: "false", p: False[int], input: 0, want: false},
+		{name: "and_both", p: And(isEven, isPositive), input: 2, want: true},
+		{name: "and_first_only", p: And(isEven, isPositive), input: -2, want: false},
+		{name: "and_second_only", p: And(isEven, isPositive), input: 1, want: false},
+		{name: "and_neither", p: And(isEven, isPositive), input: -1, want: false},
+		{name: "or_both", p: Or(isEven, isPositive), input: 2, want: true},
+		{name: "or_first_only", p: Or(isEven, isPositive), input: -2, want: true},
+		{name: "or_second_only", p: Or(isEven, isPositive), input: 1, want: true},
+		{name: "or_neither", p: Or(isEven, isPositive), input: -1, want: false},
+		{name: "xor_both", p: Xor(isEven, isPositive), input: 2, want: false},
+		{name: "xor_first_only", p: Xor(isEven, isPositive), input: -2, want: true},
+		{name: "xor_second_only", p: Xor(isEven, isPositive), input: 1, want: true},
+		{name: "xor_neither", p: Xor(isEven, isPositive), input: -1, want: false},
+		{name: "all_of_none", p: AllOf[int](), input: 0, want: true},
+		{name: "all_of_all", p: AllOf(isEven, isPositive, isSmall), input: 4, want: true},
+		{name: "all_of_some", p: AllOf(isEven, isPositive, isSmall), input: 12, want: false},
+		{name: "any_of_none", p: AnyOf[int](), input: 0, want: false},
+		{name: "any_of_some", p: AnyOf(isEven, isPositive), input: 1, want: true},
+		{name: "any_of_nothing_holds", p: AnyOf(isEven, isPositive), input: -1, want: false},
+		{name: "none_of_none", p: NoneOf[int](), input: 0, want: true},
+		{name: "none_of_some", p: NoneOf(isEven, isPositive), input: 1, want: false},
+		{name: "none_of_nothing_holds", p: NoneOf(isEven, isPositive), input: -1, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.p(tc.input); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAllOfAnyOfShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	counted := func(result bool) func(int) bool {
+		return func(int) bool {
+			calls++
+			return result
+		}
+	}
+	AllOf(counted(true), counted(false), counted(true))(0)
+	if calls != 2 {
+		t.Errorf("unexpected AllOf calls: got %d, want 2", calls)
+	}
+	calls = 0
+	AnyOf(counted(false), counted(true), counted(false))(0)
+	if calls != 2 {
+		t.Errorf("unexpected AnyOf calls: got %d, want 2", calls)
+	}
+}
+
+func TestValuePredicates(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		// holds applies the predicate under test
+		holds func() bool
+		want  bool
+	}{
+		{name: "equal_to_equal", holds: func() bool { return EqualTo("a")("a") }, want: true},
+		{name: "equal_to_different", holds: func() bool { return EqualTo("a")("b") }, want: false},
+		{name: "deep_equals_equal", holds: func() bool { return DeepEquals([]int{1, 2})([]int{1, 2}) }, want: true},
+		{name: "deep_equals_different", holds: func() bool { return DeepEquals([]int{1, 2})([]int{2, 1}) }, want: false},
+		{name: "deep_equals_nil_and_empty", holds: func() bool { return DeepEquals([]int(nil))([]int{}) }, want: false},
+		{name: "in_present", holds: func() bool { return In(map[string]int{"a": 0})("a") }, want: true},
+		{name: "in_absent", holds: func() bool { return In(map[string]int{"a": 0})("b") }, want: false},
+		{name: "in_nil_map", holds: func() bool { return In(map[string]int(nil))("a") }, want: false},
+		{name: "one_of_present", holds: func() bool { return OneOf(1, 2, 3)(2) }, want: true},
+		{name: "one_of_absent", holds: func() bool { return OneOf(1, 2, 3)(4) }, want: false},
+		{name: "one_of_nothing", holds: func() bool { return OneOf[int]()(0) }, want: false},
+		{name: "one_of_slice", holds: func() bool { return OneOf([]string{"x", "y"}...)("y") }, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.holds(); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOrderingPredicates(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		p     func(int) bool
+		input int
+		want  bool
+	}{
+		{name: "greater_than_below", p: GreaterThan(5), input: 4, want: false},
+		{name: "greater_than_boundary", p: GreaterThan(5), input: 5, want: false},
+		{name: "greater_than_above", p: GreaterThan(5), input: 6, want: true},
+		{name: "greater_eq_below", p: GreaterEq(5), input: 4, want: false},
+		{name: "greater_eq_boundary", p: GreaterEq(5), input: 5, want: true},
+		{name: "greater_eq_above", p: GreaterEq(5), input: 6, want: true},
+		{name: "less_than_below", p: LessThan(5), input: 4, want: true},
+		{name: "less_than_boundary", p: LessThan(5), input: 5, want: false},
+		{name: "less_than_above", p: LessThan(5), input: 6, want: false},
+		{name: "less_eq_below", p: LessEq(5), input: 4, want: true},
+		{name: "less_eq_boundary", p: LessEq(5), input: 5, want: true},
+		{name: "less_eq_above", p: LessEq(5), input: 6, want: false},
+		{name: "between_below", p: Between(1, 3), input: 0, want: false},
+		{name: "between_low", p: Between(1, 3), input: 1, want: true},
+		{name: "between_inside", p: Between(1, 3), input: 2, want: true},
+		{name: "between_high", p: Between(1, 3), input: 3, want: true},
+		{name: "between_above", p: Between(1, 3), input: 4, want: false},
+		{name: "between_empty_range", p: Between(3, 1), input: 2, want: false},
+		{name: "between_single_value", p: Between(2, 2), input: 2, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.p(tc.input); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOrderingPredicatesOnStrings(t *testing.T) {
+	t.Parallel()
+
+	if !Between("b", "d")("c") || Between("b", "d")("e") || !GreaterThan("a")("b") {
+		t.Errorf("unexpected result for string ordering")
+	}
+}
+
+type celsius float64
+
+type label string
+
+type handler func()
+
+func TestIsNil(t *testing.T) {
+	t.Parallel()
+
+	var nilPtr *int
+	var nilErr error
+	cases := []struct {
+		name string
+		// isNil calls IsNil with a particular type argument
+		isNil func() bool
+		want  bool
+	}{
+		{name: "nil_interface", isNil: func() bool { return IsNil[any](nil) }, want: true},
+		{name: "nil_error", isNil: func() bool { return IsNil(nilErr) }, want: true},
+		{name: "nil_pointer", isNil: func() bool { return IsNil(nilPtr) }, want: true},
+		{name: "typed_nil_pointer_in_interface", isNil: func() bool { return IsNil[any](nilPtr) }, want: true},
+		{name: "nil_map", isNil: func() bool { return IsNil(map[string]int(nil)) }, want: true},
+		{name: "nil_slice", isNil: func() bool { return IsNil([]int(nil)) }, want: true},
+		{name: "nil_chan", isNil: func() bool { return IsNil((chan int)(nil)) }, want: true},
+		{name: "nil_func", isNil: func() bool { return IsNil((func())(nil)) }, want: true},
+		{name: "nil_named_func", isNil: func() bool { return IsNil(handler(nil)) }, want: true},
+		{name: "pointer", isNil: func() bool { return IsNil(new(int)) }, want: false},
+		{name: "pointer_in_interface", isNil: func() bool { return IsNil[any](new(int)) }, want: false},
+		{name: "map", isNil: func() bool { return IsNil(map[string]int{}) }, want: false},
+		{name: "slice", isNil: func() bool { return IsNil([]int{}) }, want: false},
+		{name: "empty_slice_of_empty_structs", isNil: func() bool { return IsNil(make([]struct{}, 0)) }, want: false},
+		{name: "nil_slice_in_interface", isNil: func() bool { return IsNil[any]([]int(nil)) }, want: true},
+		{name: "chan", isNil: func() bool { return IsNil(make(chan int)) }, want: false},
+		{name: "func", isNil: func() bool { return IsNil(func() {}) }, want: false},
+		{name: "error", isNil: func() bool { return IsNil(errTest) }, want: false},
+		{name: "int", isNil: func() bool { return IsNil(0) }, want: false},
+		{name: "string", isNil: func() bool { return IsNil("") }, want: false},
+		{name: "struct", isNil: func() bool { return IsNil(struct{}{}) }, want: false},
+		{name: "named_float", isNil: func() bool { return IsNil(celsius(0)) }, want: false},
+		{name: "named_string", isNil: func() bool { return IsNil(label("")) }, want: false},
+		{name: "named_scalar_in_interface", isNil: func() bool { return IsNil[any](celsius(1)) }, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.isNil(); got != tc.want {
+				t.Errorf("unexpected IsNil: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNil(t *testing.T) {
+	t.Parallel()
+
+	var nilPtr *int
+	if !Nil[*int]()(nilPtr) || Nil[*int]()(new(int)) {
+		t.Error("unexpected Nil result for pointers")
+	}
+	if !Nil[[]int]()(nil) || Nil[[]int]()([]int{}) {
+		t.Error("unexpected Nil result for slices")
+	}
+	if !Nil[any]()(nilPtr) || Nil[any]()(0) {
+		t.Error("unexpected Nil result for interfaces")
+	}
+	if Nil[int]()(0) {
+		t.Error("unexpected Nil result for ints")
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	t.Parallel()
+
+	if !IsZero(0) || IsZero(1) || !IsZero("") || IsZero("a") || !IsZero(celsius(0)) || !IsZero[*int](nil) {
+		t.Errorf("unexpected IsZero result")
+	}
+}
+
+func TestBy(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string
+		Age  int
+	}
+	age := func(u user) int { return u.Age }
+	cases := []struct {
+		name  string
+		p     func(user) bool
+		input user
+		want  bool
+	}{
+		{name: "key_matches", p: By(age, GreaterEq(18)), input: user{Name: "ann", Age: 30}, want: true},
+		{name: "key_boundary", p: By(age, GreaterEq(18)), input: user{Name: "bob", Age: 18}, want: true},
+		{name: "key_does_not_match", p: By(age, GreaterEq(18)), input: user{Name: "cid", Age: 12}, want: false},
+		{name: "string_key", p: By(func(u user) string { return u.Name }, HasPrefix("a")), input: user{Name: "ann"}, want: true},
+		{name: "composed", p: And(By(age, LessThan(65)), By(func(u user) string { return u.Name }, OneOf("ann", "bob"))), input: user{Name: "bob", Age: 40}, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.p(tc.input); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
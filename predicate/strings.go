@@ -0,0 +1,36 @@
+package predicate
+
+import (
+	"regexp"
+	"strings"
+)
+
+func HasPrefix[S ~string](prefix S) func(S) bool {
+	return func(s S) bool {
+		return strings.HasPrefix(string(s), string(prefix))
+	}
+}
+
+func HasSuffix[S ~string](suffix S) func(S) bool {
+	return func(s S) bool {
+		return strings.HasSuffix(string(s), string(suffix))
+	}
+}
+
+func Contains[S ~string](substr S) func(S) bool {
+	return func(s S) bool {
+		return strings.Contains(string(s), string(substr))
+	}
+}
+
+func MatchesRegexp[S ~string](re *regexp.Regexp) func(S) bool {
+	return func(s S) bool {
+		return re.MatchString(string(s))
+	}
+}
+
+// MatchesPattern is like MatchesRegexp, compiling pattern once up front. It
+// panics if pattern is not a valid regular expression.
+func MatchesPattern[S ~string](pattern string) func(S) bool {
+	return MatchesRegexp[S](regexp.MustCompile(pattern))
+}
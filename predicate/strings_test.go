@@ -0,0 +1,60 @@
+package predicate
+
+import (
+	"regexp"
+	"testing"
+)
+
+type name string
+
+func TestStringPredicates(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		p     func(string) bool
+		input string
+		want  bool
+	}{
+		{name: "has_prefix", p: HasPrefix("go"), input: "gopher", want: true},
+		{name: "has_prefix_missing", p: HasPrefix("go"), input: "ago", want: false},
+		{name: "has_prefix_empty", p: HasPrefix(""), input: "gopher", want: true},
+		{name: "has_suffix", p: HasSuffix("er"), input: "gopher", want: true},
+		{name: "has_suffix_missing", p: HasSuffix("er"), input: "error", want: false},
+		{name: "contains", p: Contains("ph"), input: "gopher", want: true},
+		{name: "contains_missing", p: Contains("xy"), input: "gopher", want: false},
+		{name: "contains_in_empty", p: Contains("a"), input: "", want: false},
+		{name: "matches_regexp", p: MatchesRegexp[string](regexp.MustCompile(`^\d+$`)), input: "123", want: true},
+		{name: "matches_regexp_no_match", p: MatchesRegexp[string](regexp.MustCompile(`^\d+$`)), input: "12a", want: false},
+		{name: "matches_pattern", p: MatchesPattern[string](`o.h`), input: "gopher", want: true},
+		{name: "matches_pattern_no_match", p: MatchesPattern[string](`^h`), input: "gopher", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.p(tc.input); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringPredicatesOnNamedTypes(t *testing.T) {
+	t.Parallel()
+
+	if !HasPrefix[name]("go")("gopher") || !MatchesPattern[name](`er$`)("gopher") {
+		t.Errorf("unexpected result for a named string type")
+	}
+}
+
+func TestMatchesPatternPanicsOnInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for an invalid pattern")
+		}
+	}()
+	MatchesPattern[string](`(`)
+}
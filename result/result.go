@@ -0,0 +1,77 @@
+package result
+
+// Result holds either a value or an error. The zero Result is Ok with the zero
+// value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+func Ok[T any](t T) Result[T] {
+	return Result[T]{value: t}
+}
+
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Of adapts the (T, error) results of ordinary Go functions. The value is
+// discarded when err is non-nil.
+func Of[T any](t T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(t)
+}
+
+// From calls f and captures its outcome.
+func From[T any](f func() (T, error)) Result[T] {
+	return Of(f())
+}
+
+// Unwrap returns the value and error held by r, in the usual Go form.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.err
+}
+
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+func (r Result[T]) OrElse(t T) T {
+	if r.err != nil {
+		return t
+	}
+	return r.value
+}
+
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+func FlatMap[T, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return f(r.value)
+}
+
+// Recover replaces an error with the value computed from it by f. Ok results
+// are returned unchanged.
+func Recover[T any](r Result[T], f func(error) T) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return Ok(f(r.err))
+}
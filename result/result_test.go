@@ -0,0 +1,100 @@
+package result
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"strconv"
+	"testing"
+)
+
+type values[T any] struct {
+	Value T
+	Err   error
+}
+
+func unwrap[T any](r Result[T]) values[T] {
+	v, err := r.Unwrap()
+	return values[T]{Value: v, Err: err}
+}
+
+var errTest = errors.New("test")
+
+func TestConstructors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input Result[int]
+		want  values[int]
+	}{
+		{name: "ok", input: Ok(1), want: values[int]{Value: 1}},
+		{name: "err", input: Err[int](errTest), want: values[int]{Err: errTest}},
+		{name: "zero", input: Result[int]{}, want: values[int]{}},
+		{name: "of_ok", input: Of(2, nil), want: values[int]{Value: 2}},
+		{name: "of_err", input: Of(2, errTest), want: values[int]{Err: errTest}},
+		{name: "from_ok", input: From(func() (int, error) { return strconv.Atoi("3") }), want: values[int]{Value: 3}},
+		{name: "from_err", input: From(func() (int, error) { return 0, errTest }), want: values[int]{Err: errTest}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(unwrap(tc.input), tc.want, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.input.IsOk(), tc.want.Err == nil); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.input.IsErr(), tc.want.Err != nil); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.input.Err(), tc.want.Err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) Result[int] { return Of(strconv.Atoi(s)) }
+	double := func(i int) int { return i * 2 }
+
+	cases := []struct {
+		name string
+		got  Result[int]
+		want values[int]
+	}{
+		{name: "map_ok", got: Map(Ok(2), double), want: values[int]{Value: 4}},
+		{name: "map_err", got: Map(Err[int](errTest), double), want: values[int]{Err: errTest}},
+		{name: "flat_map_ok", got: FlatMap(Ok("12"), parse), want: values[int]{Value: 12}},
+		{name: "flat_map_err", got: FlatMap(Err[string](errTest), parse), want: values[int]{Err: errTest}},
+		{name: "flat_map_fails", got: FlatMap(Ok("x"), parse), want: values[int]{Err: strconv.ErrSyntax}},
+		{name: "recover_ok", got: Recover(Ok(1), func(error) int { return -1 }), want: values[int]{Value: 1}},
+		{name: "recover_err", got: Recover(Err[int](errTest), func(error) int { return -1 }), want: values[int]{Value: -1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(unwrap(tc.got), tc.want, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff(Ok(1).OrElse(2), 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Err[int](errTest).OrElse(2), 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,107 @@
+package set
+
+import "iter"
+
+type Set[T comparable] map[T]struct{}
+
+func New[T comparable](ts ...T) Set[T] {
+	return FromSlice(ts)
+}
+
+func FromSlice[T comparable](slice []T) Set[T] {
+	s := make(Set[T], len(slice))
+	s.Add(slice...)
+	return s
+}
+
+func FromSeq[T comparable](seq iter.Seq[T]) Set[T] {
+	s := make(Set[T])
+	for t := range seq {
+		s.Add(t)
+	}
+	return s
+}
+
+// FromChan collects every element of channel into a set, returning once the
+// channel is closed.
+func FromChan[T comparable](channel <-chan T) Set[T] {
+	s := make(Set[T])
+	for t := range channel {
+		s.Add(t)
+	}
+	return s
+}
+
+func (s Set[T]) Add(ts ...T) {
+	for _, t := range ts {
+		s[t] = struct{}{}
+	}
+}
+
+func (s Set[T]) Remove(ts ...T) {
+	for _, t := range ts {
+		delete(s, t)
+	}
+}
+
+func (s Set[T]) Contains(t T) bool {
+	_, ok := s[t]
+	return ok
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Iter yields the elements of s in unspecified order.
+func (s Set[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range s {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := make(Set[T], len(s)+len(other))
+	for t := range s {
+		union.Add(t)
+	}
+	for t := range other {
+		union.Add(t)
+	}
+	return union
+}
+
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	intersection := make(Set[T])
+	for t := range small {
+		if large.Contains(t) {
+			intersection.Add(t)
+		}
+	}
+	return intersection
+}
+
+// Difference returns the elements of s that are not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := make(Set[T])
+	for t := range s {
+		if !other.Contains(t) {
+			difference.Add(t)
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns the elements that are in exactly one of s and
+// other.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	return s.Difference(other).Union(other.Difference(s))
+}
@@ -0,0 +1,95 @@
+package set
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"slices"
+	"testing"
+)
+
+func TestConstructors(t *testing.T) {
+	t.Parallel()
+
+	channel := make(chan int, 4)
+	for _, i := range []int{1, 2, 2, 3} {
+		channel <- i
+	}
+	close(channel)
+
+	want := Set[int]{1: {}, 2: {}, 3: {}}
+	cases := []struct {
+		name string
+		got  Set[int]
+	}{
+		{name: "new", got: New(1, 2, 2, 3)},
+		{name: "from_slice", got: FromSlice([]int{1, 2, 2, 3})},
+		{name: "from_seq", got: FromSeq(slices.Values([]int{1, 2, 2, 3}))},
+		{name: "from_chan", got: FromChan(channel)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.got, want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAddRemoveContains(t *testing.T) {
+	t.Parallel()
+
+	s := New[string]()
+	s.Add("a", "b", "c")
+	s.Remove("b", "d")
+	if diff := cmp.Diff(s.Len(), 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	for _, tc := range []struct {
+		elem string
+		want bool
+	}{{"a", true}, {"b", false}, {"c", true}, {"d", false}} {
+		if got := s.Contains(tc.elem); got != tc.want {
+			t.Errorf("Contains(%q) = %v, want %v", tc.elem, got, tc.want)
+		}
+	}
+}
+
+func TestIter(t *testing.T) {
+	t.Parallel()
+
+	got := slices.Sorted(New(3, 1, 2).Iter())
+	if diff := cmp.Diff(got, []int{1, 2, 3}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestSetOps(t *testing.T) {
+	t.Parallel()
+
+	s1 := New(1, 2, 3)
+	s2 := New(2, 3, 4, 5)
+
+	cases := []struct {
+		name string
+		got  Set[int]
+		want Set[int]
+	}{
+		{name: "union", got: s1.Union(s2), want: New(1, 2, 3, 4, 5)},
+		{name: "intersect", got: s1.Intersect(s2), want: New(2, 3)},
+		{name: "difference", got: s1.Difference(s2), want: New(1)},
+		{name: "symmetric_difference", got: s1.SymmetricDifference(s2), want: New(1, 4, 5)},
+		{name: "intersect_empty", got: s1.Intersect(New[int]()), want: New[int]()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
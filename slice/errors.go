@@ -0,0 +1,47 @@
+package slice
+
+import (
+	"errors"
+)
+
+// MapErr is like Map for a function that can fail. By default it stops at the
+// first error and returns it with a nil slice, see CollectAllErrors.
+func MapErr[T, U any](slice []T, f func(T) (U, error), opts ...Option) ([]U, error) {
+	o := newOptions(opts)
+	mapped := make([]U, 0, len(slice))
+	var errs []error
+	for _, t := range slice {
+		u, err := f(t)
+		if err != nil {
+			if !o.collectErrs {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		mapped = append(mapped, u)
+	}
+	return mapped, errors.Join(errs...)
+}
+
+// FilterErr is like Filter for a predicate that can fail. By default it stops
+// at the first error and returns it with a nil slice, see CollectAllErrors.
+func FilterErr[T any](slice []T, p func(T) (bool, error), opts ...Option) ([]T, error) {
+	o := newOptions(opts)
+	var filtered []T
+	var errs []error
+	for _, t := range slice {
+		ok, err := p(t)
+		if err != nil {
+			if !o.collectErrs {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, errors.Join(errs...)
+}
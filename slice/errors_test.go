@@ -0,0 +1,160 @@
+package slice
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"slices"
+	"testing"
+)
+
+var (
+	errTwo  = errors.New("two")
+	errFour = errors.New("four")
+)
+
+// failOn returns an error for 2 and 4 and nil otherwise.
+func failOn(i int) error {
+	switch i {
+	case 2:
+		return errTwo
+	case 4:
+		return errFour
+	}
+	return nil
+}
+
+func TestMapErr(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		input     []int
+		opts      []Option
+		want      []string
+		wantErrs  []error
+		wantCalls []int
+	}{
+		{
+			name:      "empty",
+			input:     nil,
+			want:      []string{},
+			wantCalls: nil,
+		},
+		{
+			name:      "no_errors",
+			input:     []int{1, 3},
+			want:      []string{"1", "3"},
+			wantCalls: []int{1, 3},
+		},
+		{
+			name:      "fail_fast",
+			input:     []int{1, 2, 3, 4, 5},
+			want:      nil,
+			wantErrs:  []error{errTwo},
+			wantCalls: []int{1, 2},
+		},
+		{
+			name:      "collect_all_errors",
+			input:     []int{1, 2, 3, 4, 5},
+			opts:      []Option{CollectAllErrors()},
+			want:      []string{"1", "3", "5"},
+			wantErrs:  []error{errTwo, errFour},
+			wantCalls: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []int
+			got, err := MapErr(tc.input, func(i int) (string, error) {
+				calls = append(calls, i)
+				return fmt.Sprint(i), failOn(i)
+			}, tc.opts...)
+			checkErrs(t, err, tc.wantErrs)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(calls, tc.wantCalls); diff != "" {
+				t.Errorf("unexpected calls (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterErr(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		input     []int
+		opts      []Option
+		want      []int
+		wantErrs  []error
+		wantCalls []int
+	}{
+		{
+			name:      "empty",
+			input:     nil,
+			want:      nil,
+			wantCalls: nil,
+		},
+		{
+			name:      "no_errors",
+			input:     []int{1, 3, 5},
+			want:      []int{1, 5},
+			wantCalls: []int{1, 3, 5},
+		},
+		{
+			name:      "fail_fast",
+			input:     []int{1, 2, 3, 4, 5},
+			want:      nil,
+			wantErrs:  []error{errTwo},
+			wantCalls: []int{1, 2},
+		},
+		{
+			name:      "collect_all_errors",
+			input:     []int{1, 2, 3, 4, 5},
+			opts:      []Option{CollectAllErrors()},
+			want:      []int{1, 5},
+			wantErrs:  []error{errTwo, errFour},
+			wantCalls: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []int
+			got, err := FilterErr(tc.input, func(i int) (bool, error) {
+				calls = append(calls, i)
+				return i != 3, failOn(i)
+			}, tc.opts...)
+			checkErrs(t, err, tc.wantErrs)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(calls, tc.wantCalls); diff != "" {
+				t.Errorf("unexpected calls (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// checkErrs fails the test unless err is nil when want is empty, or wraps
+// exactly the errors of want otherwise.
+func checkErrs(t *testing.T, err error, want []error) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Errorf("got error %v, want %v", err, nil)
+		}
+		return
+	}
+	for _, e := range []error{errTwo, errFour} {
+		if errors.Is(err, e) != slices.Contains(want, e) {
+			t.Errorf("got error %v, want one wrapping exactly %v", err, want)
+		}
+	}
+}
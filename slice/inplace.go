@@ -0,0 +1,36 @@
+package slice
+
+// RemoveIf removes the elements of slice for which p holds, in place. It
+// returns slice truncated to the remaining elements and zeroes the elements
+// past the new length so they can be garbage collected.
+func RemoveIf[T any](slice []T, p func(T) bool) []T {
+	kept := 0
+	for _, t := range slice {
+		if !p(t) {
+			slice[kept] = t
+			kept++
+		}
+	}
+	clear(slice[kept:])
+	return slice[:kept]
+}
+
+// CompactZero removes the zero values of slice in place, see RemoveIf.
+func CompactZero[T comparable](slice []T) []T {
+	var zero T
+	return RemoveIf(slice, func(t T) bool { return t == zero })
+}
+
+// DedupInPlace removes every element of slice that is equal to an earlier
+// one in place, see RemoveIf. Unlike Distinct, no new slice is allocated,
+// although the set of seen elements still is.
+func DedupInPlace[T comparable](slice []T) []T {
+	seen := make(map[T]struct{}, len(slice))
+	return RemoveIf(slice, func(t T) bool {
+		if _, ok := seen[t]; ok {
+			return true
+		}
+		seen[t] = struct{}{}
+		return false
+	})
+}
@@ -0,0 +1,88 @@
+package slice
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestInPlace(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(i int) bool { return i%2 == 0 }
+	cases := []struct {
+		name string
+		// apply runs the function under test on input
+		apply func(input []int) []int
+		input []int
+		want  []int
+		// wantBacking is input after the call, including the zeroed tail
+		wantBacking []int
+	}{
+		{
+			name:        "remove_if_empty",
+			apply:       func(input []int) []int { return RemoveIf(input, isEven) },
+			input:       []int{},
+			want:        []int{},
+			wantBacking: []int{},
+		},
+		{
+			name:        "remove_if_none",
+			apply:       func(input []int) []int { return RemoveIf(input, isEven) },
+			input:       []int{1, 3, 5},
+			want:        []int{1, 3, 5},
+			wantBacking: []int{1, 3, 5},
+		},
+		{
+			name:        "remove_if_some_zeroes_tail",
+			apply:       func(input []int) []int { return RemoveIf(input, isEven) },
+			input:       []int{1, 2, 3, 4, 5, 6},
+			want:        []int{1, 3, 5},
+			wantBacking: []int{1, 3, 5, 0, 0, 0},
+		},
+		{
+			name:        "remove_if_all",
+			apply:       func(input []int) []int { return RemoveIf(input, isEven) },
+			input:       []int{2, 4},
+			want:        []int{},
+			wantBacking: []int{0, 0},
+		},
+		{
+			name:        "compact_zero",
+			apply:       CompactZero[int],
+			input:       []int{0, 1, 0, 0, 2, 0},
+			want:        []int{1, 2},
+			wantBacking: []int{1, 2, 0, 0, 0, 0},
+		},
+		{
+			name:        "dedup_in_place",
+			apply:       DedupInPlace[int],
+			input:       []int{3, 1, 3, 2, 1},
+			want:        []int{3, 1, 2},
+			wantBacking: []int{3, 1, 2, 0, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := tc.apply(tc.input)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.input, tc.wantBacking); diff != "" {
+				t.Errorf("unexpected backing array (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRemoveIfReleasesPointers(t *testing.T) {
+	t.Parallel()
+
+	one, two := 1, 2
+	input := []*int{&one, &two}
+	RemoveIf(input, func(p *int) bool { return *p == 1 })
+	if input[1] != nil {
+		t.Errorf("expected the element past the new length to be nil, got %v", input[1])
+	}
+}
@@ -0,0 +1,38 @@
+package slice
+
+import (
+	"runtime"
+)
+
+// Option configures the behavior of the operators that accept it.
+type Option func(*options)
+
+type options struct {
+	workers     int
+	collectErrs bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{workers: runtime.NumCPU()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithWorkers sets the number of worker goroutines used by the parallel
+// operators. Defaults to runtime.NumCPU().
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = max(n, 1)
+	}
+}
+
+// CollectAllErrors makes MapErr and FilterErr process every element and return
+// the errors of all failed elements joined together, along with the results of
+// the elements that succeeded. By default they stop at the first error.
+func CollectAllErrors() Option {
+	return func(o *options) {
+		o.collectErrs = true
+	}
+}
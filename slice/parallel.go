@@ -0,0 +1,27 @@
+package slice
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelMap is like Map but applies f to the elements of slice concurrently
+// using a bounded pool of workers, see WithWorkers. Every result is written to
+// the index of the element it was mapped from, so the order is preserved.
+func ParallelMap[T, U any](slice []T, f func(T) U, opts ...Option) []U {
+	o := newOptions(opts)
+	mapped := make([]U, len(slice))
+	next := atomic.Int64{}
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < min(o.workers, len(slice)); i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for j := next.Add(1) - 1; j < int64(len(slice)); j = next.Add(1) - 1 {
+				mapped[j] = f(slice[j])
+			}
+		}()
+	}
+	waitGroup.Wait()
+	return mapped
+}
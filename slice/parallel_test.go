@@ -0,0 +1,87 @@
+package slice
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestParallelMap(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		opts  []Option
+		want  []string
+	}{
+		{
+			name:  "nil",
+			input: nil,
+			want:  []string{},
+		},
+		{
+			name:  "empty",
+			input: []int{},
+			want:  []string{},
+		},
+		{
+			name:  "order_preserved",
+			input: []int{5, 4, 3, 2, 1, 0, 9, 8, 7, 6},
+			opts:  []Option{WithWorkers(4)},
+			want:  []string{"5", "4", "3", "2", "1", "0", "9", "8", "7", "6"},
+		},
+		{
+			name:  "single_worker",
+			input: []int{1, 2, 3},
+			opts:  []Option{WithWorkers(1)},
+			want:  []string{"1", "2", "3"},
+		},
+		{
+			name:  "more_workers_than_elements",
+			input: []int{1, 2, 3},
+			opts:  []Option{WithWorkers(10)},
+			want:  []string{"1", "2", "3"},
+		},
+		{
+			name:  "non_positive_workers",
+			input: []int{1, 2, 3},
+			opts:  []Option{WithWorkers(0)},
+			want:  []string{"1", "2", "3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(ParallelMap(tc.input, strconv.Itoa, tc.opts...), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParallelMapBoundsWorkers(t *testing.T) {
+	t.Parallel()
+
+	for _, workers := range []int{1, 3} {
+		var mu sync.Mutex
+		running, peak := 0, 0
+		ParallelMap(make([]int, 50), func(int) int {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				running--
+				mu.Unlock()
+			}()
+			return 0
+		}, WithWorkers(workers))
+		if peak > workers {
+			t.Errorf("unexpected concurrency with %d workers: got %d", workers, peak)
+		}
+	}
+}
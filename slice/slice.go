@@ -2,8 +2,11 @@ package slice
 
 import (
 	"errors"
+	"github.com/lock14/functional/option"
+	"github.com/lock14/functional/tuple"
 	"golang.org/x/exp/constraints"
 	"iter"
+	"slices"
 )
 
 // Monad represents any type that can use the `+` operator and whose zero
@@ -44,6 +47,80 @@ func Filter[T any](slice []T, p func(T) bool) []T {
 	return filtered
 }
 
+// FilterMap transforms and filters slice in a single pass, keeping f(t) for
+// every t for which f reports true.
+func FilterMap[T, U any](slice []T, f func(T) (U, bool)) []U {
+	var mapped []U
+	for _, t := range slice {
+		if u, ok := f(t); ok {
+			mapped = append(mapped, u)
+		}
+	}
+	return mapped
+}
+
+func MapOption[T, U any](slice []T, f func(T) option.Option[U]) []U {
+	return FilterMap(slice, func(t T) (U, bool) { return f(t).Get() })
+}
+
+// Distinct returns the distinct elements of slice in order of their first
+// occurrence.
+func Distinct[T comparable](slice []T) []T {
+	return DistinctBy(slice, func(t T) T { return t })
+}
+
+// DistinctBy returns the elements of slice with distinct keys, keeping the
+// first element for every key.
+func DistinctBy[T any, K comparable](slice []T, keyFn func(T) K) []T {
+	var distinct []T
+	seen := make(map[K]struct{})
+	for _, t := range slice {
+		key := keyFn(t)
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			distinct = append(distinct, t)
+		}
+	}
+	return distinct
+}
+
+// TakeWhile returns the longest prefix of slice whose elements all satisfy p.
+// The prefix shares the backing array of slice, but its capacity is limited so
+// appending to it does not overwrite the rest of slice.
+func TakeWhile[T any](slice []T, p func(T) bool) []T {
+	for i, t := range slice {
+		if !p(t) {
+			return slice[:i:i]
+		}
+	}
+	return slice
+}
+
+// DropWhile returns slice without the longest prefix whose elements all
+// satisfy p.
+func DropWhile[T any](slice []T, p func(T) bool) []T {
+	return slice[len(TakeWhile(slice, p)):]
+}
+
+// SplitWhen splits slice around the elements satisfying p, which are not
+// included in the result. Like strings.Split, adjacent separators produce
+// empty sub-slices. The sub-slices share the backing array of slice, but their
+// capacity is limited so appending to one does not overwrite another.
+func SplitWhen[T any](slice []T, p func(T) bool) [][]T {
+	if len(slice) == 0 {
+		return nil
+	}
+	var split [][]T
+	start := 0
+	for i, t := range slice {
+		if p(t) {
+			split = append(split, slice[start:i:i])
+			start = i + 1
+		}
+	}
+	return append(split, slice[start:len(slice):len(slice)])
+}
+
 func FoldLeft[T any, U any](slice []T, f func(u U, t T) U, u U) U {
 	result := u
 	for _, t := range slice {
@@ -64,11 +141,89 @@ func Reduce[T any](slice []T, op func(t1, t2 T) T, initial T) T {
 	return FoldLeft(slice, op, initial)
 }
 
+// Scan is like FoldLeft but returns every intermediate result, starting with
+// the result after the first element.
+func Scan[T, U any](slice []T, initial U, f func(U, T) U) []U {
+	scanned := make([]U, 0, len(slice))
+	result := initial
+	for _, t := range slice {
+		result = f(result, t)
+		scanned = append(scanned, result)
+	}
+	return scanned
+}
+
+// CumSum returns the prefix sums of numbers.
+func CumSum[M Monad](numbers []M) []M {
+	var identity M
+	return Scan(numbers, identity, func(a, b M) M { return a + b })
+}
+
 func Sum[M Monad](numbers []M) M {
 	var identity M
 	return Reduce(numbers, func(a, b M) M { return a + b }, identity)
 }
 
+// CountBy counts the elements of slice per key.
+func CountBy[T any, K comparable](slice []T, keyFn func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, t := range slice {
+		counts[keyFn(t)]++
+	}
+	return counts
+}
+
+// SumBy sums the values f projects the elements of slice to.
+func SumBy[T any, M Monad](slice []T, f func(T) M) M {
+	return Sum(Map(slice, f))
+}
+
+// MinBy returns the smallest element of slice according to cmp, or false if
+// slice is empty. If several elements are smallest, the first one is returned.
+func MinBy[T any](slice []T, cmp func(a, b T) int) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+	result := slice[0]
+	for _, t := range slice[1:] {
+		if cmp(t, result) < 0 {
+			result = t
+		}
+	}
+	return result, true
+}
+
+// MaxBy returns the greatest element of slice according to cmp, or false if
+// slice is empty. If several elements are greatest, the first one is
+// returned.
+func MaxBy[T any](slice []T, cmp func(a, b T) int) (T, bool) {
+	return MinBy(slice, func(a, b T) int { return cmp(b, a) })
+}
+
+// EqualBy reports whether slice1 and slice2 have the same length and eq
+// holds for every pair of elements at the same index.
+func EqualBy[T any](slice1, slice2 []T, eq func(T, T) bool) bool {
+	if len(slice1) != len(slice2) {
+		return false
+	}
+	for i := range slice1 {
+		if !eq(slice1[i], slice2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func ContainsFunc[T any](slice []T, p func(T) bool) bool {
+	for _, t := range slice {
+		if p(t) {
+			return true
+		}
+	}
+	return false
+}
+
 func JoinErrs(errs []error) error {
 	return Reduce(errs, func(e1, e2 error) error { return errors.Join(e1, e2) }, nil)
 }
@@ -83,33 +238,37 @@ func Join[T ~string](strings []T, sep T) T {
 	return first + Reduce(strings, func(a, b T) T { return a + sep + b }, "")
 }
 
-type Pair[T1, T2 any] struct {
-	fst T1
-	snd T2
+func Zip[T, U any](slice1 []T, slice2 []U) []tuple.Pair[T, U] {
+	return ZipWith(slice1, slice2, func(t T, u U) tuple.Pair[T, U] { return tuple.Pair[T, U]{Fst: t, Snd: u} })
 }
 
-func Zip[T, U any](slice1 []T, slice2 []U) []Pair[T, U] {
+func ZipWith[T, U, V any](slice1 []T, slice2 []U, f func(T, U) V) []V {
 	len1 := len(slice1)
 	len2 := len(slice2)
 	minLen := len1
 	if len2 < minLen {
 		minLen = len2
 	}
-	zipped := make([]Pair[T, U], 0, minLen)
+	zipped := make([]V, 0, minLen)
 	for i := 0; i < minLen; i++ {
-		zipped = append(zipped, Pair[T, U]{slice1[i], slice2[i]})
+		zipped = append(zipped, f(slice1[i], slice2[i]))
 	}
 	return zipped
 }
 
-func UnZip[T, U any](slice []Pair[T, U]) ([]T, []U) {
-	ts := make([]T, 0, len(slice))
+func UnZip[T, U any](slice []tuple.Pair[T, U]) ([]T, []U) {
+	return UnZipWith(slice, func(p tuple.Pair[T, U]) (T, U) { return p.Fst, p.Snd })
+}
+
+func UnZipWith[T, U, V any](slice []T, split func(T) (U, V)) ([]U, []V) {
 	us := make([]U, 0, len(slice))
-	for _, p := range slice {
-		ts = append(ts, p.fst)
-		us = append(us, p.snd)
+	vs := make([]V, 0, len(slice))
+	for _, t := range slice {
+		u, v := split(t)
+		us = append(us, u)
+		vs = append(vs, v)
 	}
-	return ts, us
+	return us, vs
 }
 
 func Concat[T any](slice1, slice2 []T) []T {
@@ -123,6 +282,28 @@ func Concat[T any](slice1, slice2 []T) []T {
 	return c
 }
 
+// Rotated returns a copy of slice rotated left by n positions, or right if n
+// is negative.
+func Rotated[T any](slice []T, n int) []T {
+	if len(slice) == 0 {
+		return []T{}
+	}
+	n = ((n % len(slice)) + len(slice)) % len(slice)
+	return Concat(slice[n:], slice[:n])
+}
+
+// Inserted returns a copy of slice with vals inserted at index i. It panics if
+// i is out of range.
+func Inserted[T any](slice []T, i int, vals ...T) []T {
+	return slices.Insert(slices.Clone(slice), i, vals...)
+}
+
+// Removed returns a copy of slice without the element at index i. It panics if
+// i is out of range.
+func Removed[T any](slice []T, i int) []T {
+	return Concat(slice[:i], slice[i+1:])
+}
+
 func Partition[T any](slice []T, size int) [][]T {
 	partitioned := make([][]T, 0, len(slice)/size+1)
 	count := 0
@@ -144,6 +325,57 @@ func Partition[T any](slice []T, size int) [][]T {
 	return partitioned
 }
 
+// Windows returns the sub-slices of slice of length size, starting a new
+// window every step elements. The windows share the backing array of slice,
+// but their capacity is limited so appending to one does not overwrite
+// another.
+func Windows[T any](slice []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 || len(slice) < size {
+		return nil
+	}
+	windows := make([][]T, 0, (len(slice)-size)/step+1)
+	for i := 0; i+size <= len(slice); i += step {
+		windows = append(windows, slice[i:i+size:i+size])
+	}
+	return windows
+}
+
+// Associate builds a map from the key-value pairs returned by f for every
+// element of slice. Later pairs overwrite earlier ones with the same key.
+func Associate[T any, K comparable, V any](slice []T, f func(T) (K, V)) map[K]V {
+	m := make(map[K]V, len(slice))
+	for _, t := range slice {
+		k, v := f(t)
+		m[k] = v
+	}
+	return m
+}
+
+// ToMap indexes the elements of slice by keyFn. When several elements have
+// the same key, resolve is called with the key, the element already in the
+// map, and the incoming element to determine the element to keep, such as
+// maps.KeepFirst. A nil resolve keeps the last element.
+func ToMap[T any, K comparable](slice []T, keyFn func(T) K, resolve func(k K, existing, incoming T) T) map[K]T {
+	m := make(map[K]T, len(slice))
+	for _, t := range slice {
+		k := keyFn(t)
+		if existing, ok := m[k]; ok && resolve != nil {
+			m[k] = resolve(k, existing, t)
+		} else {
+			m[k] = t
+		}
+	}
+	return m
+}
+
+func ToSet[T comparable](slice []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(slice))
+	for _, t := range slice {
+		set[t] = struct{}{}
+	}
+	return set
+}
+
 func Collect[T, U any](seq2 iter.Seq2[T, U]) ([]T, []U) {
 	var ts []T
 	var us []U
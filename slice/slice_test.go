@@ -0,0 +1,914 @@
+package slice
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/maps"
+	"github.com/lock14/functional/tuple"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWindows(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		size  int
+		step  int
+		want  [][]int
+	}{
+		{
+			name:  "nil",
+			input: nil,
+			size:  2,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "empty",
+			input: []int{},
+			size:  1,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "zero_size",
+			input: []int{1, 2, 3},
+			size:  0,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "negative_size",
+			input: []int{1, 2, 3},
+			size:  -1,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "zero_step",
+			input: []int{1, 2, 3},
+			size:  2,
+			step:  0,
+			want:  nil,
+		},
+		{
+			name:  "negative_step",
+			input: []int{1, 2, 3},
+			size:  2,
+			step:  -1,
+			want:  nil,
+		},
+		{
+			name:  "size_larger_than_input",
+			input: []int{1, 2, 3},
+			size:  4,
+			step:  1,
+			want:  nil,
+		},
+		{
+			name:  "size_equal_to_input",
+			input: []int{1, 2, 3},
+			size:  3,
+			step:  1,
+			want:  [][]int{{1, 2, 3}},
+		},
+		{
+			name:  "sliding",
+			input: []int{1, 2, 3, 4},
+			size:  2,
+			step:  1,
+			want:  [][]int{{1, 2}, {2, 3}, {3, 4}},
+		},
+		{
+			name:  "tumbling",
+			input: []int{1, 2, 3, 4},
+			size:  2,
+			step:  2,
+			want:  [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:  "incomplete_last_window_dropped",
+			input: []int{1, 2, 3, 4, 5},
+			size:  2,
+			step:  2,
+			want:  [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:  "step_larger_than_size",
+			input: []int{1, 2, 3, 4, 5, 6, 7},
+			size:  2,
+			step:  3,
+			want:  [][]int{{1, 2}, {4, 5}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(Windows(tc.input, tc.size, tc.step), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWindowsDoNotOverwriteEachOther(t *testing.T) {
+	t.Parallel()
+
+	input := []int{1, 2, 3, 4}
+	windows := Windows(input, 2, 1)
+	_ = append(windows[0], 10)
+	if diff := cmp.Diff(input, []int{1, 2, 3, 4}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "nil",
+			input: nil,
+			want:  nil,
+		},
+		{
+			name:  "no_duplicates",
+			input: []int{3, 1, 2},
+			want:  []int{3, 1, 2},
+		},
+		{
+			name:  "duplicates_keep_first_occurrence_order",
+			input: []int{3, 1, 3, 2, 1, 3},
+			want:  []int{3, 1, 2},
+		},
+		{
+			name:  "all_equal",
+			input: []int{7, 7, 7},
+			want:  []int{7},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(Distinct(tc.input), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDistinctBy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{
+			name:  "empty",
+			input: []string{},
+			want:  nil,
+		},
+		{
+			name:  "keeps_first_element_per_key",
+			input: []string{"apple", "avocado", "banana", "blueberry", "cherry"},
+			want:  []string{"apple", "banana", "cherry"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := DistinctBy(tc.input, func(s string) byte { return s[0] })
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []string
+		want  map[byte]int
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  map[byte]int{},
+		},
+		{
+			name:  "later_pairs_overwrite_earlier",
+			input: []string{"apple", "avocado", "fig"},
+			want:  map[byte]int{'a': 7, 'f': 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := Associate(tc.input, func(s string) (byte, int) { return s[0], len(s) })
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	t.Parallel()
+
+	words := []string{"apple", "avocado", "banana", "apricot"}
+	firstLetter := func(s string) byte { return s[0] }
+	cases := []struct {
+		name    string
+		input   []string
+		resolve func(byte, string, string) string
+		want    map[byte]string
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  map[byte]string{},
+		},
+		{
+			name:  "nil_resolve_keeps_last",
+			input: words,
+			want:  map[byte]string{'a': "apricot", 'b': "banana"},
+		},
+		{
+			name:    "keep_first",
+			input:   words,
+			resolve: maps.KeepFirst[byte, string],
+			want:    map[byte]string{'a': "apple", 'b': "banana"},
+		},
+		{
+			name:    "keep_last",
+			input:   words,
+			resolve: maps.KeepLast[byte, string],
+			want:    map[byte]string{'a': "apricot", 'b': "banana"},
+		},
+		{
+			name:    "custom_resolve",
+			input:   words,
+			resolve: func(_ byte, existing, incoming string) string { return existing + "+" + incoming },
+			want:    map[byte]string{'a': "apple+avocado+apricot", 'b': "banana"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(ToMap(tc.input, firstLetter, tc.resolve), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestToSet(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  map[int]struct{}
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  map[int]struct{}{},
+		},
+		{
+			name:  "duplicates",
+			input: []int{1, 2, 1, 3, 2},
+			want:  map[int]struct{}{1: {}, 2: {}, 3: {}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(ToSet(tc.input), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	t.Parallel()
+
+	isSmall := func(i int) bool { return i < 3 }
+	cases := []struct {
+		name     string
+		input    []int
+		wantTake []int
+		wantDrop []int
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			wantTake: nil,
+			wantDrop: nil,
+		},
+		{
+			name:     "all_match",
+			input:    []int{0, 1, 2},
+			wantTake: []int{0, 1, 2},
+			wantDrop: []int{},
+		},
+		{
+			name:     "none_match",
+			input:    []int{3, 1},
+			wantTake: []int{},
+			wantDrop: []int{3, 1},
+		},
+		{
+			name:     "prefix_matches",
+			input:    []int{1, 2, 5, 1},
+			wantTake: []int{1, 2},
+			wantDrop: []int{5, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(TakeWhile(tc.input, isSmall), tc.wantTake); diff != "" {
+				t.Errorf("unexpected TakeWhile result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(DropWhile(tc.input, isSmall), tc.wantDrop); diff != "" {
+				t.Errorf("unexpected DropWhile result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTakeWhileDoesNotOverwriteRest(t *testing.T) {
+	t.Parallel()
+
+	input := []int{1, 2, 5, 1}
+	_ = append(TakeWhile(input, func(i int) bool { return i < 3 }), 10)
+	if diff := cmp.Diff(input, []int{1, 2, 5, 1}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestSplitWhen(t *testing.T) {
+	t.Parallel()
+
+	isZero := func(i int) bool { return i == 0 }
+	cases := []struct {
+		name  string
+		input []int
+		want  [][]int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			want:  nil,
+		},
+		{
+			name:  "no_separator",
+			input: []int{1, 2},
+			want:  [][]int{{1, 2}},
+		},
+		{
+			name:  "separators_inside",
+			input: []int{1, 0, 2, 3, 0, 4},
+			want:  [][]int{{1}, {2, 3}, {4}},
+		},
+		{
+			name:  "leading_separator",
+			input: []int{0, 1, 2},
+			want:  [][]int{{}, {1, 2}},
+		},
+		{
+			name:  "trailing_separator",
+			input: []int{1, 2, 0},
+			want:  [][]int{{1, 2}, {}},
+		},
+		{
+			name:  "adjacent_separators",
+			input: []int{1, 0, 0, 2},
+			want:  [][]int{{1}, {}, {2}},
+		},
+		{
+			name:  "only_separator",
+			input: []int{0},
+			want:  [][]int{{}, {}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(SplitWhen(tc.input, isZero), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitWhenDoesNotOverwriteInput(t *testing.T) {
+	t.Parallel()
+
+	input := []int{1, 0, 2}
+	split := SplitWhen(input, func(i int) bool { return i == 0 })
+	_ = append(split[0], 10)
+	if diff := cmp.Diff(input, []int{1, 0, 2}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestZipUnZip(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		ints     []int
+		strs     []string
+		want     []tuple.Pair[int, string]
+		wantInts []int
+		wantStrs []string
+	}{
+		{
+			name:     "empty",
+			ints:     nil,
+			strs:     nil,
+			want:     []tuple.Pair[int, string]{},
+			wantInts: []int{},
+			wantStrs: []string{},
+		},
+		{
+			name:     "same_length",
+			ints:     []int{1, 2},
+			strs:     []string{"a", "b"},
+			want:     []tuple.Pair[int, string]{{Fst: 1, Snd: "a"}, {Fst: 2, Snd: "b"}},
+			wantInts: []int{1, 2},
+			wantStrs: []string{"a", "b"},
+		},
+		{
+			name:     "truncated_to_shorter",
+			ints:     []int{1, 2, 3},
+			strs:     []string{"a"},
+			want:     []tuple.Pair[int, string]{{Fst: 1, Snd: "a"}},
+			wantInts: []int{1},
+			wantStrs: []string{"a"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			zipped := Zip(tc.ints, tc.strs)
+			if diff := cmp.Diff(zipped, tc.want); diff != "" {
+				t.Errorf("unexpected Zip result (-got, +want): %s", diff)
+			}
+			ints, strs := UnZip(zipped)
+			if diff := cmp.Diff(ints, tc.wantInts); diff != "" {
+				t.Errorf("unexpected UnZip result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(strs, tc.wantStrs); diff != "" {
+				t.Errorf("unexpected UnZip result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []string
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  []string{},
+		},
+		{
+			name:  "intermediate_results",
+			input: []int{1, 2, 3},
+			want:  []string{">1", ">12", ">123"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := Scan(tc.input, ">", func(acc string, i int) string { return acc + strconv.Itoa(i) })
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "empty",
+			input: []int{},
+			want:  []int{},
+		},
+		{
+			name:  "prefix_sums",
+			input: []int{1, -2, 3, 4},
+			want:  []int{1, -1, 2, 6},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(CumSum(tc.input), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCountBySumBy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		input     []string
+		wantCount map[byte]int
+		wantSum   int
+	}{
+		{
+			name:      "empty",
+			input:     nil,
+			wantCount: map[byte]int{},
+			wantSum:   0,
+		},
+		{
+			name:      "many",
+			input:     []string{"apple", "avocado", "fig"},
+			wantCount: map[byte]int{'a': 2, 'f': 1},
+			wantSum:   15,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(CountBy(tc.input, func(s string) byte { return s[0] }), tc.wantCount); diff != "" {
+				t.Errorf("unexpected CountBy result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(SumBy(tc.input, func(s string) int { return len(s) }), tc.wantSum); diff != "" {
+				t.Errorf("unexpected SumBy result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	t.Parallel()
+
+	byLen := func(a, b string) int { return len(a) - len(b) }
+	cases := []struct {
+		name    string
+		input   []string
+		wantMin string
+		wantMax string
+		wantOk  bool
+	}{
+		{
+			name:   "empty",
+			input:  nil,
+			wantOk: false,
+		},
+		{
+			name:    "one",
+			input:   []string{"kiwi"},
+			wantMin: "kiwi",
+			wantMax: "kiwi",
+			wantOk:  true,
+		},
+		{
+			name:    "ties_keep_first",
+			input:   []string{"fig", "apple", "kiwi", "pea", "mango"},
+			wantMin: "fig",
+			wantMax: "apple",
+			wantOk:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gotMin, okMin := MinBy(tc.input, byLen)
+			gotMax, okMax := MaxBy(tc.input, byLen)
+			if okMin != tc.wantOk || okMax != tc.wantOk {
+				t.Errorf("unexpected ok: got (%v, %v), want %v", okMin, okMax, tc.wantOk)
+			}
+			if diff := cmp.Diff(gotMin, tc.wantMin); diff != "" {
+				t.Errorf("unexpected min (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(gotMax, tc.wantMax); diff != "" {
+				t.Errorf("unexpected max (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRotated(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		input []int
+		n     int
+		want  []int
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			n:     3,
+			want:  []int{},
+		},
+		{
+			name:  "zero",
+			input: []int{1, 2, 3},
+			n:     0,
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "left",
+			input: []int{1, 2, 3, 4},
+			n:     1,
+			want:  []int{2, 3, 4, 1},
+		},
+		{
+			name:  "right",
+			input: []int{1, 2, 3, 4},
+			n:     -1,
+			want:  []int{4, 1, 2, 3},
+		},
+		{
+			name:  "full_turn",
+			input: []int{1, 2, 3},
+			n:     3,
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "more_than_length",
+			input: []int{1, 2, 3},
+			n:     7,
+			want:  []int{2, 3, 1},
+		},
+		{
+			name:  "negative_more_than_length",
+			input: []int{1, 2, 3},
+			n:     -7,
+			want:  []int{3, 1, 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			input := slices.Clone(tc.input)
+			if diff := cmp.Diff(Rotated(input, tc.n), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(input, tc.input); diff != "" {
+				t.Errorf("input was modified (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInsertedRemoved(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		// apply runs the function under test on input
+		apply func(input []int) []int
+		input []int
+		want  []int
+	}{
+		{
+			name:  "inserted_into_empty",
+			apply: func(input []int) []int { return Inserted(input, 0, 1, 2) },
+			input: []int{},
+			want:  []int{1, 2},
+		},
+		{
+			name:  "inserted_in_middle",
+			apply: func(input []int) []int { return Inserted(input, 1, 7, 8) },
+			input: []int{1, 2, 3},
+			want:  []int{1, 7, 8, 2, 3},
+		},
+		{
+			name:  "inserted_at_end",
+			apply: func(input []int) []int { return Inserted(input, 3, 4) },
+			input: []int{1, 2, 3},
+			want:  []int{1, 2, 3, 4},
+		},
+		{
+			name:  "removed_first",
+			apply: func(input []int) []int { return Removed(input, 0) },
+			input: []int{1, 2, 3},
+			want:  []int{2, 3},
+		},
+		{
+			name:  "removed_last",
+			apply: func(input []int) []int { return Removed(input, 2) },
+			input: []int{1, 2, 3},
+			want:  []int{1, 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			input := slices.Clone(tc.input)
+			if diff := cmp.Diff(tc.apply(input), tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(input, tc.input); diff != "" {
+				t.Errorf("input was modified (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		slice1 []int
+		slice2 []string
+		want   []string
+	}{
+		{
+			name:   "empty",
+			slice1: nil,
+			slice2: []string{"a"},
+			want:   []string{},
+		},
+		{
+			name:   "truncated_to_shorter",
+			slice1: []int{1, 2, 3},
+			slice2: []string{"a", "b"},
+			want:   []string{"a1", "b2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ZipWith(tc.slice1, tc.slice2, func(i int, s string) string { return s + strconv.Itoa(i) })
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnZipWith(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		input     []string
+		wantHeads []byte
+		wantLens  []int
+	}{
+		{
+			name:      "empty",
+			input:     nil,
+			wantHeads: []byte{},
+			wantLens:  []int{},
+		},
+		{
+			name:      "many",
+			input:     []string{"apple", "fig"},
+			wantHeads: []byte{'a', 'f'},
+			wantLens:  []int{5, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			heads, lens := UnZipWith(tc.input, func(s string) (byte, int) { return s[0], len(s) })
+			if diff := cmp.Diff(heads, tc.wantHeads); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(lens, tc.wantLens); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestEqualBy(t *testing.T) {
+	t.Parallel()
+
+	sameLen := func(a, b string) bool { return len(a) == len(b) }
+	cases := []struct {
+		name   string
+		slice1 []string
+		slice2 []string
+		want   bool
+	}{
+		{
+			name:   "both_empty",
+			slice1: nil,
+			slice2: []string{},
+			want:   true,
+		},
+		{
+			name:   "equal",
+			slice1: []string{"ab", "c"},
+			slice2: []string{"xy", "z"},
+			want:   true,
+		},
+		{
+			name:   "element_differs",
+			slice1: []string{"ab", "c"},
+			slice2: []string{"xy", "zz"},
+			want:   false,
+		},
+		{
+			name:   "length_differs",
+			slice1: []string{"ab"},
+			slice2: []string{"xy", "z"},
+			want:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := EqualBy(tc.slice1, tc.slice2, sameLen); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContainsFunc(t *testing.T) {
+	t.Parallel()
+
+	isNegative := func(i int) bool { return i < 0 }
+	cases := []struct {
+		name  string
+		input []int
+		want  bool
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  false,
+		},
+		{
+			name:  "absent",
+			input: []int{1, 2},
+			want:  false,
+		},
+		{
+			name:  "present",
+			input: []int{1, -2, 3},
+			want:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ContainsFunc(tc.input, isNegative); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
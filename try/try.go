@@ -0,0 +1,49 @@
+package try
+
+import (
+	"fmt"
+	"github.com/lock14/functional/iterator"
+	"github.com/lock14/functional/result"
+	"iter"
+	"runtime/debug"
+)
+
+// PanicError is the error a recovered panic is converted into.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Of calls f, capturing a panic as a *PanicError.
+func Of[T any](f func() T) result.Result[T] {
+	return Of2(func() (T, error) { return f(), nil })
+}
+
+// Of2 calls f, capturing both its error and a panic, which is converted into
+// a *PanicError.
+func Of2[T any](f func() (T, error)) (r result.Result[T]) {
+	defer func() {
+		if v := recover(); v != nil {
+			r = result.Err[T](&PanicError{Value: v, Stack: debug.Stack()})
+		}
+	}()
+	return result.From(f)
+}
+
+// MapSeq is like iterator.Map, but a panic in f is yielded as an error instead
+// of crashing the program.
+func MapSeq[T, U any](itr iter.Seq[T], f func(T) U) iter.Seq[result.Result[U]] {
+	return iterator.Map(itr, func(t T) result.Result[U] {
+		return Of(func() U { return f(t) })
+	})
+}
@@ -0,0 +1,93 @@
+package try
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lock14/functional/result"
+	"slices"
+	"testing"
+)
+
+var errTest = errors.New("test")
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		got       result.Result[int]
+		want      int
+		wantPanic any
+		wantErr   error
+	}{
+		{
+			name: "of_ok",
+			got:  Of(func() int { return 1 }),
+			want: 1,
+		},
+		{
+			name:      "of_panic",
+			got:       Of(func() int { panic("boom") }),
+			wantPanic: "boom",
+		},
+		{
+			name: "of2_ok",
+			got:  Of2(func() (int, error) { return 2, nil }),
+			want: 2,
+		},
+		{
+			name:    "of2_err",
+			got:     Of2(func() (int, error) { return 0, errTest }),
+			wantErr: errTest,
+		},
+		{
+			name:      "of2_panic_with_error",
+			got:       Of2(func() (int, error) { panic(errTest) }),
+			wantPanic: errTest,
+			wantErr:   errTest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.got.Unwrap()
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("got error %v, want %v", err, tc.wantErr)
+			}
+			var panicErr *PanicError
+			if isPanic := errors.As(err, &panicErr); isPanic != (tc.wantPanic != nil) {
+				t.Fatalf("got error %v, want panic %v", err, tc.wantPanic)
+			} else if isPanic && panicErr.Value != tc.wantPanic {
+				t.Errorf("got panic value %v, want %v", panicErr.Value, tc.wantPanic)
+			}
+			if tc.wantErr == nil && tc.wantPanic == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMapSeq(t *testing.T) {
+	t.Parallel()
+
+	var values []int
+	var errs int
+	for r := range MapSeq(slices.Values([]int{1, 0, 2}), func(i int) int { return 100 / i }) {
+		if v, err := r.Unwrap(); err != nil {
+			errs++
+		} else {
+			values = append(values, v)
+		}
+	}
+	if diff := cmp.Diff(values, []int{100, 50}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(errs, 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
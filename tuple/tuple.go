@@ -0,0 +1,67 @@
+package tuple
+
+type Pair[A, B any] struct {
+	Fst A
+	Snd B
+}
+
+type Triple[A, B, C any] struct {
+	Fst A
+	Snd B
+	Thd C
+}
+
+type Quad[A, B, C, D any] struct {
+	Fst A
+	Snd B
+	Thd C
+	Fth D
+}
+
+func NewPair[A, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{Fst: a, Snd: b}
+}
+
+func NewTriple[A, B, C any](a A, b B, c C) Triple[A, B, C] {
+	return Triple[A, B, C]{Fst: a, Snd: b, Thd: c}
+}
+
+func NewQuad[A, B, C, D any](a A, b B, c C, d D) Quad[A, B, C, D] {
+	return Quad[A, B, C, D]{Fst: a, Snd: b, Thd: c, Fth: d}
+}
+
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.Fst, p.Snd
+}
+
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.Fst, t.Snd, t.Thd
+}
+
+func (q Quad[A, B, C, D]) Unpack() (A, B, C, D) {
+	return q.Fst, q.Snd, q.Thd, q.Fth
+}
+
+// First returns the first element of p. It is meant to be passed to Map-like
+// operators, as in slice.Map(pairs, tuple.First[string, int]).
+func First[A, B any](p Pair[A, B]) A {
+	return p.Fst
+}
+
+// Second returns the second element of p, see First.
+func Second[A, B any](p Pair[A, B]) B {
+	return p.Snd
+}
+
+func MapFirst[A, B, C any](p Pair[A, B], f func(A) C) Pair[C, B] {
+	return Pair[C, B]{Fst: f(p.Fst), Snd: p.Snd}
+}
+
+func MapSecond[A, B, C any](p Pair[A, B], f func(B) C) Pair[A, C] {
+	return Pair[A, C]{Fst: p.Fst, Snd: f(p.Snd)}
+}
+
+// Swap returns p with its elements exchanged.
+func Swap[A, B any](p Pair[A, B]) Pair[B, A] {
+	return Pair[B, A]{Fst: p.Snd, Snd: p.Fst}
+}
@@ -0,0 +1,59 @@
+package tuple
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"strconv"
+	"testing"
+)
+
+func TestConstructors(t *testing.T) {
+	t.Parallel()
+
+	if diff := cmp.Diff(NewPair("a", 1), Pair[string, int]{Fst: "a", Snd: 1}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(NewTriple("a", 1, true), Triple[string, int, bool]{Fst: "a", Snd: 1, Thd: true}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(NewQuad("a", 1, true, 2.5), Quad[string, int, bool, float64]{Fst: "a", Snd: 1, Thd: true, Fth: 2.5}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewPair("a", 1).Unpack()
+	if diff := cmp.Diff([]any{a, b}, []any{"a", 1}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	a, b, c := NewTriple("a", 1, true).Unpack()
+	if diff := cmp.Diff([]any{a, b, c}, []any{"a", 1, true}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	a, b, c, d := NewQuad("a", 1, true, 2.5).Unpack()
+	if diff := cmp.Diff([]any{a, b, c, d}, []any{"a", 1, true, 2.5}); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
+
+func TestPairFunctions(t *testing.T) {
+	t.Parallel()
+
+	p := NewPair(1, 2)
+	if diff := cmp.Diff(First(p), 1); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Second(p), 2); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(MapFirst(p, strconv.Itoa), NewPair("1", 2)); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(MapSecond(p, strconv.Itoa), NewPair(1, "2")); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+	if diff := cmp.Diff(Swap(NewPair("a", 1)), NewPair(1, "a")); diff != "" {
+		t.Errorf("unexpected result (-got, +want): %s", diff)
+	}
+}
@@ -0,0 +1,86 @@
+package validation
+
+import "github.com/lock14/functional/slice"
+
+// Validator checks a value, returning nil if it is valid.
+type Validator[T any] func(T) error
+
+// Validated holds a value together with every error found while validating
+// it. Unlike a Result, combining Validated values keeps the errors of all of
+// them rather than stopping at the first.
+type Validated[T any] struct {
+	value T
+	errs  []error
+}
+
+func Valid[T any](t T) Validated[T] {
+	return Validated[T]{value: t}
+}
+
+// Invalid returns a Validated holding errs. Nil errors are ignored, so
+// Invalid with only nil errors is valid.
+func Invalid[T any](errs ...error) Validated[T] {
+	return Validated[T]{errs: slice.Filter(errs, func(err error) bool { return err != nil })}
+}
+
+// Validate runs every validator on t and collects all of their errors.
+func Validate[T any](t T, validators ...Validator[T]) Validated[T] {
+	v := Invalid[T](slice.Map(validators, func(validator Validator[T]) error { return validator(t) })...)
+	if v.IsValid() {
+		v.value = t
+	}
+	return v
+}
+
+func (v Validated[T]) IsValid() bool {
+	return len(v.errs) == 0
+}
+
+func (v Validated[T]) Errs() []error {
+	return v.errs
+}
+
+// Unwrap returns the value, or the zero value and all errors joined together
+// if v is invalid.
+func (v Validated[T]) Unwrap() (T, error) {
+	return v.value, slice.JoinErrs(v.errs)
+}
+
+// Combine returns a validator that runs every one of validators and joins all
+// of their errors.
+func Combine[T any](validators ...Validator[T]) Validator[T] {
+	return func(t T) error {
+		_, err := Validate(t, validators...).Unwrap()
+		return err
+	}
+}
+
+// Each returns a validator for slices that runs validator on every element
+// and joins the errors of all invalid elements.
+func Each[T any](validator Validator[T]) Validator[[]T] {
+	return func(ts []T) error {
+		_, err := slice.MapErr(ts, func(t T) (struct{}, error) {
+			return struct{}{}, validator(t)
+		}, slice.CollectAllErrors())
+		return err
+	}
+}
+
+// Apply2 combines two independently validated values with f. If either is
+// invalid, the result holds the errors of both.
+func Apply2[A, B, C any](va Validated[A], vb Validated[B], f func(A, B) C) Validated[C] {
+	errs := slice.Concat(va.errs, vb.errs)
+	if len(errs) > 0 {
+		return Validated[C]{errs: errs}
+	}
+	return Valid(f(va.value, vb.value))
+}
+
+// Apply3 is like Apply2 for three values.
+func Apply3[A, B, C, D any](va Validated[A], vb Validated[B], vc Validated[C], f func(A, B, C) D) Validated[D] {
+	errs := slice.Concat(slice.Concat(va.errs, vb.errs), vc.errs)
+	if len(errs) > 0 {
+		return Validated[D]{errs: errs}
+	}
+	return Valid(f(va.value, vb.value, vc.value))
+}
@@ -0,0 +1,167 @@
+package validation
+
+import (
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"strings"
+	"testing"
+)
+
+var (
+	errEmpty   = errors.New("empty")
+	errTooLong = errors.New("too long")
+	errUpper   = errors.New("upper case")
+)
+
+func notEmpty(s string) error {
+	if s == "" {
+		return errEmpty
+	}
+	return nil
+}
+
+func shorterThan(n int) Validator[string] {
+	return func(s string) error {
+		if len(s) >= n {
+			return errTooLong
+		}
+		return nil
+	}
+}
+
+func lowerCase(s string) error {
+	if strings.ToLower(s) != s {
+		return errUpper
+	}
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    string
+		want     string
+		wantErrs []error
+	}{
+		{
+			name:  "validate_valid",
+			input: "abc",
+			want:  "abc",
+		},
+		{
+			name:     "validate_one_error",
+			input:    "",
+			wantErrs: []error{errEmpty},
+		},
+		{
+			name:     "validate_all_errors",
+			input:    "ABCDEF",
+			wantErrs: []error{errTooLong, errUpper},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := Validate(tc.input, notEmpty, shorterThan(5), lowerCase)
+			if diff := cmp.Diff(v.Errs(), tc.wantErrs, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(v.IsValid(), len(tc.wantErrs) == 0); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			got, err := v.Unwrap()
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			for _, wantErr := range tc.wantErrs {
+				if !errors.Is(err, wantErr) {
+					t.Errorf("got error %v, want it to wrap %v", err, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestCombineAndEach(t *testing.T) {
+	t.Parallel()
+
+	validator := Each(Combine(notEmpty, lowerCase))
+	if err := validator([]string{"a", "b"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := validator([]string{"a", "", "B"})
+	for _, wantErr := range []error{errEmpty, errUpper} {
+		if !errors.Is(err, wantErr) {
+			t.Errorf("got error %v, want it to wrap %v", err, wantErr)
+		}
+	}
+}
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	errAge := errors.New("bad age")
+	validAge := func(age int) Validated[int] {
+		if age < 0 {
+			return Invalid[int](errAge)
+		}
+		return Valid(age)
+	}
+	newUser := func(name string, age int) user { return user{Name: name, Age: age} }
+
+	cases := []struct {
+		name     string
+		got      Validated[user]
+		want     user
+		wantErrs []error
+	}{
+		{
+			name: "apply_valid",
+			got:  Apply2(Validate("bob", notEmpty), validAge(3), newUser),
+			want: user{Name: "bob", Age: 3},
+		},
+		{
+			name:     "apply_collects_all",
+			got:      Apply2(Validate("", notEmpty), validAge(-1), newUser),
+			wantErrs: []error{errEmpty, errAge},
+		},
+		{
+			name: "apply3_valid",
+			got: Apply3(Validate("bob", notEmpty), validAge(3), Valid(1), func(name string, age, bonus int) user {
+				return newUser(name, age+bonus)
+			}),
+			want: user{Name: "bob", Age: 4},
+		},
+		{
+			name: "apply3_collects_all",
+			got: Apply3(Validate("B", lowerCase), validAge(3), Invalid[int](nil, errTooLong), func(name string, age, bonus int) user {
+				return newUser(name, age+bonus)
+			}),
+			wantErrs: []error{errUpper, errTooLong},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, _ := tc.got.Unwrap()
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(tc.got.Errs(), tc.wantErrs, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected result (-got, +want): %s", diff)
+			}
+		})
+	}
+}